@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// actionTimeout bounds how long an exec action may run.
+const actionTimeout = 30 * time.Second
+
+// maxActionOutput caps the command output recorded in an ActionResult.
+const maxActionOutput = 4096
+
+// ActionResult records the outcome of an --on-alert action.
+type ActionResult struct {
+	Action  string `json:"action"`
+	OK      bool   `json:"ok"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	Skipped string `json:"skipped,omitempty"` // reason the action did not run
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// alertAction is a parsed --on-alert value.
+type alertAction struct {
+	raw     string
+	kind    string // exec, kill, or signal
+	command string
+	sig     syscall.Signal
+}
+
+// parseAlertAction parses "exec:<cmd>", "kill", or "signal:<name>".
+func parseAlertAction(s string) (alertAction, error) {
+	kind, arg, _ := strings.Cut(s, ":")
+	switch strings.ToLower(kind) {
+	case "exec":
+		if strings.TrimSpace(arg) == "" {
+			return alertAction{}, fmt.Errorf("invalid action %q: exec requires a command", s)
+		}
+		return alertAction{raw: s, kind: "exec", command: arg}, nil
+	case "kill":
+		if arg != "" {
+			return alertAction{}, fmt.Errorf("invalid action %q: kill takes no argument (use signal:<name>)", s)
+		}
+		return alertAction{raw: s, kind: "kill", sig: syscall.SIGTERM}, nil
+	case "signal":
+		sig, err := process.ParseSignal(arg)
+		if err != nil {
+			return alertAction{}, fmt.Errorf("invalid action %q: %w", s, err)
+		}
+		return alertAction{raw: s, kind: "signal", sig: sig}, nil
+	default:
+		return alertAction{}, fmt.Errorf("unknown action %q (use exec:<cmd>, kill, or signal:<name>)", s)
+	}
+}
+
+// actionKey identifies an action run against a process for rate limiting.
+type actionKey struct {
+	action string
+	pid    int
+}
+
+// actionRunner runs --on-alert actions, allowing each action to run at most
+// once per cooldown for a given process.
+type actionRunner struct {
+	actions  []alertAction
+	cooldown time.Duration
+	dryRun   bool
+	lastRun  map[actionKey]time.Time
+}
+
+func newActionRunner(specs []string, cooldown time.Duration, dryRun bool) (*actionRunner, error) {
+	r := &actionRunner{
+		cooldown: cooldown,
+		dryRun:   dryRun,
+		lastRun:  make(map[actionKey]time.Time),
+	}
+	for _, spec := range specs {
+		a, err := parseAlertAction(spec)
+		if err != nil {
+			return nil, err
+		}
+		r.actions = append(r.actions, a)
+	}
+	return r, nil
+}
+
+// run executes every configured action for alert and returns their results.
+func (r *actionRunner) run(alert Alert, now time.Time) []ActionResult {
+	var results []ActionResult
+	for _, a := range r.actions {
+		key := actionKey{action: a.raw, pid: alert.Process.PID}
+		if last, ok := r.lastRun[key]; ok && now.Sub(last) < r.cooldown {
+			results = append(results, ActionResult{
+				Action:  a.raw,
+				Skipped: fmt.Sprintf("rate limited (last run %s ago)", now.Sub(last).Round(time.Second)),
+			})
+			continue
+		}
+		r.lastRun[key] = now
+
+		if r.dryRun {
+			results = append(results, ActionResult{
+				Action: a.raw,
+				OK:     true,
+				DryRun: true,
+				Output: a.describe(alert),
+			})
+			continue
+		}
+		results = append(results, a.execute(alert))
+	}
+	return results
+}
+
+// describe returns what the action would do for alert.
+func (a alertAction) describe(alert Alert) string {
+	if a.kind == "exec" {
+		return fmt.Sprintf("would run %q", a.command)
+	}
+	return fmt.Sprintf("would send %s to PID %d", process.SignalName(a.sig), alert.Process.PID)
+}
+
+// execute performs the action for alert.
+func (a alertAction) execute(alert Alert) ActionResult {
+	result := ActionResult{Action: a.raw}
+
+	if a.kind == "exec" {
+		out, err := runAlertCommand(a.command, alert)
+		result.Output = out
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.OK = true
+		return result
+	}
+
+	if err := process.KillWithSignal(alert.Process.PID, a.sig); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.OK = true
+	result.Output = fmt.Sprintf("sent %s to PID %d", process.SignalName(a.sig), alert.Process.PID)
+	return result
+}
+
+// runAlertCommand runs command through the shell with the alert JSON on
+// stdin and PID, NAME, VALUE, THRESHOLD, and LIMIT in the environment.
+func runAlertCommand(command string, alert Alert) (string, error) {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode alert: %w", err)
+	}
+	return runHook(command, payload, []string{
+		"PID=" + strconv.Itoa(alert.Process.PID),
+		"NAME=" + alert.Process.Name,
+		"VALUE=" + strconv.FormatFloat(alert.Value, 'f', 1, 64),
+		"THRESHOLD=" + alert.Threshold,
+		"LIMIT=" + strconv.FormatFloat(alert.Limit, 'f', 1, 64),
+	})
+}
+
+// runHook runs command through the shell with stdin as its input and env
+// appended to the current environment, returning its combined output.
+func runHook(command string, stdin []byte, env []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Env = append(os.Environ(), env...)
+
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if len(output) > maxActionOutput {
+		output = output[:maxActionOutput] + "..."
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("command timed out after %s", actionTimeout)
+	}
+	if err != nil {
+		return output, fmt.Errorf("command failed: %w", err)
+	}
+	return output, nil
+}
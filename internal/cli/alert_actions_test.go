@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestParseAlertAction(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantKind string
+		wantSig  syscall.Signal
+		wantErr  bool
+	}{
+		{"kill", "kill", syscall.SIGTERM, false},
+		{"signal:USR1", "signal", syscall.SIGUSR1, false},
+		{"signal:SIGHUP", "signal", syscall.SIGHUP, false},
+		{"exec:echo hi", "exec", 0, false},
+		{"exec:", "", 0, true},
+		{"kill:9", "", 0, true},
+		{"signal:BOGUS", "", 0, true},
+		{"notify", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseAlertAction(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAlertAction(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.kind != tt.wantKind {
+				t.Errorf("kind = %q, want %q", got.kind, tt.wantKind)
+			}
+			if got.sig != tt.wantSig {
+				t.Errorf("sig = %v, want %v", got.sig, tt.wantSig)
+			}
+		})
+	}
+}
+
+func TestActionRunnerDryRunAndRateLimit(t *testing.T) {
+	runner, err := newActionRunner([]string{"kill", "signal:USR1"}, time.Minute, true)
+	if err != nil {
+		t.Fatalf("newActionRunner() error: %v", err)
+	}
+
+	alert := Alert{Threshold: "cpu", Value: 99, Limit: 80, Process: process.Info{PID: 999999, Name: "spin"}}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results := runner.run(alert, now)
+	if len(results) != 2 {
+		t.Fatalf("run() returned %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if !r.OK || !r.DryRun {
+			t.Errorf("result %+v, want ok dry run", r)
+		}
+	}
+	if !strings.Contains(results[1].Output, "SIGUSR1") {
+		t.Errorf("dry run output = %q, should mention SIGUSR1", results[1].Output)
+	}
+
+	// Within the cooldown both actions are skipped.
+	results = runner.run(alert, now.Add(30*time.Second))
+	for _, r := range results {
+		if r.Skipped == "" {
+			t.Errorf("result %+v, want rate limited", r)
+		}
+	}
+
+	// A different process is not affected by the first one's cooldown.
+	other := alert
+	other.Process.PID = 999998
+	for _, r := range runner.run(other, now.Add(30*time.Second)) {
+		if r.Skipped != "" {
+			t.Errorf("result for other PID %+v, want not skipped", r)
+		}
+	}
+
+	// After the cooldown the actions run again.
+	for _, r := range runner.run(alert, now.Add(2*time.Minute)) {
+		if r.Skipped != "" {
+			t.Errorf("result after cooldown %+v, want not skipped", r)
+		}
+	}
+}
+
+func TestActionRunnerExec(t *testing.T) {
+	runner, err := newActionRunner([]string{`exec:printf '%s %s ' "$PID" "$NAME"; cat`}, time.Minute, false)
+	if err != nil {
+		t.Fatalf("newActionRunner() error: %v", err)
+	}
+
+	alert := Alert{Threshold: "mem", Value: 42, Limit: 30, Process: process.Info{PID: 123, Name: "leaky"}}
+	results := runner.run(alert, time.Now())
+	if len(results) != 1 {
+		t.Fatalf("run() returned %d results, want 1", len(results))
+	}
+	r := results[0]
+	if !r.OK {
+		t.Fatalf("exec action failed: %s", r.Error)
+	}
+	if !strings.HasPrefix(r.Output, "123 leaky ") {
+		t.Errorf("output = %q, want prefix %q", r.Output, "123 leaky ")
+	}
+
+	var got Alert
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(r.Output, "123 leaky ")), &got); err != nil {
+		t.Fatalf("stdin was not the alert JSON: %v", err)
+	}
+	if got.Process.PID != 123 || got.Threshold != "mem" {
+		t.Errorf("stdin alert = %+v, want PID 123 mem", got)
+	}
+}
+
+func TestActionRunnerExecFailure(t *testing.T) {
+	runner, err := newActionRunner([]string{"exec:exit 3"}, time.Minute, false)
+	if err != nil {
+		t.Fatalf("newActionRunner() error: %v", err)
+	}
+	results := runner.run(Alert{Process: process.Info{PID: 1}}, time.Now())
+	if len(results) != 1 || results[0].OK || results[0].Error == "" {
+		t.Errorf("results = %+v, want one failed result", results)
+	}
+}
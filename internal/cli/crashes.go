@@ -1,39 +1,129 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/config"
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
 var (
 	crashesLast    string
+	crashesSince   string
+	crashesUntil   string
 	crashesProcess string
+	crashesDirs    []string
+	crashesLogs    []string
+	crashesGroup   bool
+	crashesFrames  int
+	crashesThreads bool
+	crashesFollow  bool
+	crashesPoll    time.Duration
+	crashesExec    string
+	crashesLive    bool
+	crashesMin     int
+	crashesWindow  time.Duration
 )
 
 var crashesCmd = &cobra.Command{
 	Use:   "crashes",
 	Short: "Show recent crash reports and app hangs",
 	Long: `List recent crash reports, app hangs, spin reports, and kernel panics
-from macOS DiagnosticReports.
+from macOS DiagnosticReports, plus Linux Apport and systemd-coredump crashes.
 
-Scans ~/Library/Logs/DiagnosticReports/ and /Library/Logs/DiagnosticReports/
-for .ips (crash), .hang (app hang), .spin (spin), and .panic (kernel panic) files.
+By default scans ~/Library/Logs/DiagnosticReports/ and
+/Library/Logs/DiagnosticReports/ for .ips (crash), .hang (app hang), .spin
+(spin), and .panic (kernel panic) files, /var/crash/ for Apport .crash files,
+and /var/lib/systemd/coredump/ for core dumps.
+
+Use --dir (repeatable) to scan other directories instead, such as a folder
+of reports copied from another machine. Directories can also be set in the
+config file; --dir takes precedence.
+
+Crashes written by language runtimes are found in log files matching the
+--log glob patterns (repeatable) or the config file's "logs" entries: Go
+panics and Python tracebacks anywhere in a log, and JVM hs_err_pid*.log fatal
+error logs, which are also picked up from the report directories. The format
+is detected from the contents. Go and Python crashes are listed as
+<file>:<line>, which can be passed to crashes info; their process name is
+the Python script or, for Go, the log file name.
+
+With --live, cross-reference crashes in the last --window with the running
+processes and list those that crashed at least --min-crashes times and have
+been restarted since, with their current PID, uptime, and crash count.
+
+Config file ($PSTOP_CONFIG, or ~/.config/pstop/config.json):
+  {"crashes": {"dirs": ["~/Library/Logs/DiagnosticReports", "~/qa-reports"],
+               "logs": ["/var/log/myapp/*.log", "~/services/*/hs_err_pid*.log"]}}
 
 Examples:
   pstop crashes                      # List crashes from last 7 days
   pstop crashes --last 24h           # Last 24 hours
   pstop crashes --last 30d           # Last 30 days
+  pstop crashes --last 90m           # Last 90 minutes (m, h, d, w)
+  pstop crashes --since 2026-02-01 --until 2026-02-08
+  pstop crashes --since 2026-02-10T09:00:00Z --until 2h
   pstop crashes --process Safari     # Filter by process name
+  pstop crashes --dir ./reports      # Scan a copied-over folder
+  pstop crashes --log '/var/log/api/*.log'  # Include Go/Python/JVM crashes
+  pstop crashes --group              # Group identical crashes by signature
+  pstop crashes stats --last 30d     # Crashes per day and top crashers
+  pstop crashes --follow             # Print new reports as they are written
+  pstop crashes --follow --process MyApp --exec 'say "MyApp crashed"'
+  pstop crashes --live               # Processes crash-looping in the last hour
+  pstop crashes --live --min-crashes 5 --window 30m
   pstop crashes info <path>          # Show details of a specific report
   pstop crashes --json               # Output as JSON`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		reports, err := process.ListCrashReports(crashesLast, crashesProcess)
+		dirs, err := crashDirs()
 		if err != nil {
-			return fmt.Errorf("failed to list crash reports: %w", err)
+			return err
+		}
+		if crashesLive {
+			if crashesFollow || crashesGroup {
+				return fmt.Errorf("--live cannot be combined with --follow or --group")
+			}
+			return liveCrashLoops(dirs)
+		}
+		if crashesFollow {
+			if crashesGroup {
+				return fmt.Errorf("--follow cannot be combined with --group")
+			}
+			return followCrashes(dirs)
+		}
+		if crashesExec != "" {
+			return fmt.Errorf("--exec requires --follow")
+		}
+
+		reports, err := listCrashes(cmd, dirs)
+		if err != nil {
+			return err
+		}
+
+		if crashesGroup {
+			if crashesFrames < 0 {
+				return fmt.Errorf("--frames must not be negative")
+			}
+			groups := process.GroupCrashReports(reports, crashesFrames)
+			if jsonFlag {
+				return printJSON(groups)
+			}
+			if len(groups) == 0 {
+				printNoCrashes()
+				return nil
+			}
+			printCrashGroups(groups)
+			return nil
 		}
 
 		if jsonFlag {
@@ -44,11 +134,7 @@ Examples:
 		}
 
 		if len(reports) == 0 {
-			if crashesProcess != "" {
-				fmt.Printf("No crash reports found for %q in the last %s\n", crashesProcess, crashesLast)
-			} else {
-				fmt.Printf("No crash reports found in the last %s\n", crashesLast)
-			}
+			printNoCrashes()
 			return nil
 		}
 
@@ -57,10 +143,64 @@ Examples:
 	},
 }
 
+var crashesStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show crashes per day and the top crashing processes",
+	Long: `Show a per-day histogram of crash reports and the processes that crash
+most often. Accepts the same --last, --process, and --dir flags as crashes.
+
+Examples:
+  pstop crashes stats                # Last 7 days
+  pstop crashes stats --last 30d
+  pstop crashes stats --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs, err := crashDirs()
+		if err != nil {
+			return err
+		}
+		reports, err := listCrashes(cmd, dirs)
+		if err != nil {
+			return err
+		}
+
+		stats := crashStats{
+			Total:     len(reports),
+			Days:      process.DailyCrashCounts(reports),
+			Processes: topCrashers(reports),
+		}
+		if jsonFlag {
+			if stats.Days == nil {
+				stats.Days = []process.CrashDay{}
+			}
+			return printJSON(stats)
+		}
+
+		if len(reports) == 0 {
+			printNoCrashes()
+			return nil
+		}
+		printCrashStats(stats)
+		return nil
+	},
+}
+
 var crashesInfoCmd = &cobra.Command{
 	Use:   "info <report-path>",
 	Short: "Show details of a specific crash report",
-	Long:  `Display detailed information about a specific crash report file.`,
+	Long: `Display detailed information about a specific crash report file.
+
+Frames without symbols are shown as "image + offset" together with the load
+address, and the binary images they refer to are listed with their UUIDs so
+the report can be symbolicated. Use --all-threads to print every thread; the
+thread that triggered the crash is marked.
+
+For hang and spin reports, the heaviest stack of the main thread shows where
+it was stuck; --all-threads prints the sampled call tree of every thread.
+
+Any other file is searched for Go panics and Python tracebacks. Give the
+location listed by crashes, such as app.log:120, to select one crash; the
+last crash in the file is shown otherwise.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		detail, err := process.GetCrashDetail(args[0])
@@ -78,18 +218,363 @@ var crashesInfoCmd = &cobra.Command{
 }
 
 func init() {
-	crashesCmd.Flags().StringVar(&crashesLast, "last", "7d", "Time window (e.g., 24h, 7d, 30d)")
-	crashesCmd.Flags().StringVar(&crashesProcess, "process", "", "Filter by process name")
+	crashesCmd.PersistentFlags().StringVar(&crashesLast, "last", "7d", "Time window (e.g., 30m, 24h, 7d, 2w)")
+	crashesCmd.PersistentFlags().StringVar(&crashesSince, "since", "", "Only reports at or after this time (RFC3339, YYYY-MM-DD, or relative like 2d) instead of --last")
+	crashesCmd.PersistentFlags().StringVar(&crashesUntil, "until", "", "Only reports at or before this time (RFC3339, YYYY-MM-DD, or relative like 1h)")
+	crashesCmd.PersistentFlags().StringVar(&crashesProcess, "process", "", "Filter by process name")
+	crashesCmd.PersistentFlags().StringArrayVar(&crashesDirs, "dir", nil, "Report directory to scan instead of the defaults (repeatable)")
+	crashesCmd.PersistentFlags().StringArrayVar(&crashesLogs, "log", nil, "Glob of log files to search for Go, JVM, and Python crashes instead of the config file's (repeatable)")
+	crashesCmd.Flags().BoolVar(&crashesGroup, "group", false, "Group reports by crash signature")
+	crashesCmd.Flags().IntVar(&crashesFrames, "frames", 5, "Backtrace frames to include in the signature with --group")
+	crashesCmd.Flags().BoolVar(&crashesFollow, "follow", false, "Keep running and print new reports as they appear")
+	crashesCmd.Flags().DurationVar(&crashesPoll, "interval", 2*time.Second, "How often to check for new reports with --follow")
+	crashesCmd.Flags().StringVar(&crashesExec, "exec", "", "Shell command to run for each new report with --follow (report JSON on stdin)")
+	crashesCmd.Flags().BoolVar(&crashesLive, "live", false, "List running processes that are crash-looping")
+	crashesCmd.Flags().IntVar(&crashesMin, "min-crashes", 3, "Crashes within --window that make a crash loop (used with --live)")
+	crashesCmd.Flags().DurationVar(&crashesWindow, "window", time.Hour, "Time window for counting crashes (used with --live)")
+	crashesInfoCmd.Flags().BoolVar(&crashesThreads, "all-threads", false, "Print the backtrace or call tree of every thread")
 	crashesCmd.AddCommand(crashesInfoCmd)
+	crashesCmd.AddCommand(crashesStatsCmd)
 	rootCmd.AddCommand(crashesCmd)
 }
 
+// crashDirs returns the report directories to scan: --dir if given, else
+// the config file entries, else the built-in defaults.
+func crashDirs() ([]string, error) {
+	if len(crashesDirs) > 0 {
+		dirs := make([]string, len(crashesDirs))
+		for i, d := range crashesDirs {
+			dirs[i] = config.ExpandHome(d)
+		}
+		return dirs, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Crashes.Dirs) > 0 {
+		return cfg.Crashes.Dirs, nil
+	}
+	return process.DefaultCrashDirs(), nil
+}
+
+// crashLogs returns the log file globs to search for runtime crashes: --log
+// if given, else the config file entries.
+func crashLogs() ([]string, error) {
+	if len(crashesLogs) > 0 {
+		logs := make([]string, len(crashesLogs))
+		for i, l := range crashesLogs {
+			logs[i] = config.ExpandHome(l)
+		}
+		return logs, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Crashes.Logs, nil
+}
+
+// followCrashes polls dirs for new reports until interrupted, printing each
+// one as a table row or JSON Line and running the --exec hook for it.
+func followCrashes(dirs []string) error {
+	if crashesPoll <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	logs, err := crashLogs()
+	if err != nil {
+		return err
+	}
+	follower := process.NewCrashFollower(dirs, logs)
+	ticker := time.NewTicker(crashesPoll)
+	defer ticker.Stop()
+
+	if !jsonFlag {
+		fmt.Fprintf(os.Stderr, "Watching %s for new crash reports. Press Ctrl+C to stop.\n",
+			strings.Join(dirs, ", "))
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	header := false
+
+	for {
+		select {
+		case <-sigCh:
+			return nil
+		case <-ticker.C:
+		}
+
+		for _, r := range follower.Poll() {
+			if crashesProcess != "" && !strings.EqualFold(r.Process, crashesProcess) {
+				continue
+			}
+
+			if jsonFlag {
+				if err := printJSONLine(r); err != nil {
+					return err
+				}
+			} else {
+				if !header {
+					fmt.Fprintln(w, "TIMESTAMP\tPROCESS\tTYPE\tSIGNAL\tPATH")
+					header = true
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					r.Timestamp, r.Process, r.ReportType, r.Signal, r.Location())
+				w.Flush()
+			}
+
+			if crashesExec != "" {
+				if out, err := runCrashHook(crashesExec, r); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: --exec for %s: %v\n", r.Path, err)
+					if out != "" {
+						fmt.Fprintln(os.Stderr, out)
+					}
+				} else if out != "" && !jsonFlag {
+					fmt.Println(out)
+				}
+			}
+		}
+	}
+}
+
+// runCrashHook runs the --exec command for a new report, with the report JSON
+// on stdin and its main fields in the environment.
+func runCrashHook(command string, r process.CrashReport) (string, error) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report: %w", err)
+	}
+	return runHook(command, payload, []string{
+		"PID=" + strconv.Itoa(r.PID),
+		"NAME=" + r.Process,
+		"SIGNAL=" + r.Signal,
+		"REPORT_TYPE=" + r.ReportType,
+		"REPORT_PATH=" + r.Path,
+		"REPORT_LINE=" + strconv.Itoa(r.Line),
+	})
+}
+
+// findCrashLoops returns the running processes with at least minCrashes
+// reports matching q within window.
+func findCrashLoops(q process.CrashQuery, minCrashes int, window time.Duration) ([]process.CrashLoop, error) {
+	now := time.Now()
+	q.Since = now.Add(-window)
+	reports := process.QueryCrashReports(q)
+	if len(reports) == 0 {
+		return nil, nil
+	}
+	procs, err := process.List()
+	if err != nil {
+		return nil, err
+	}
+	return process.DetectCrashLoops(reports, procs, process.StartTime, minCrashes, window, now), nil
+}
+
+// liveCrashLoops implements crashes --live.
+func liveCrashLoops(dirs []string) error {
+	if crashesMin < 1 {
+		return fmt.Errorf("--min-crashes must be at least 1")
+	}
+	if crashesWindow <= 0 {
+		return fmt.Errorf("--window must be positive")
+	}
+	logs, err := crashLogs()
+	if err != nil {
+		return err
+	}
+	q := process.CrashQuery{Dirs: dirs, Logs: logs, Process: crashesProcess}
+	loops, err := findCrashLoops(q, crashesMin, crashesWindow)
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		if len(loops) == 0 {
+			return printJSON([]process.CrashLoop{})
+		}
+		return printJSON(loops)
+	}
+	if len(loops) == 0 {
+		fmt.Printf("No running process crashed %d or more times in the last %s\n", crashesMin, crashesWindow)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROCESS\tPID\tCRASHES\tLAST CRASH\tUPTIME")
+	for _, l := range loops {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n",
+			l.Process, l.PID, l.Crashes, formatSeen(l.LastCrash), l.Uptime().Round(time.Second))
+	}
+	w.Flush()
+	return nil
+}
+
+// listCrashes returns the reports in dirs that match the --last, --since,
+// --until, and --process flags. With --until but no --since, --last counts
+// back from --until.
+func listCrashes(cmd *cobra.Command, dirs []string) ([]process.CrashReport, error) {
+	if crashesSince != "" && cmd.Flags().Changed("last") {
+		return nil, fmt.Errorf("--last and --since cannot be combined")
+	}
+
+	logs, err := crashLogs()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	q := process.CrashQuery{Dirs: dirs, Logs: logs, Process: crashesProcess}
+	if crashesUntil != "" {
+		if q.Until, err = process.ParseTimeBound(crashesUntil, now); err != nil {
+			return nil, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if crashesSince != "" {
+		if q.Since, err = process.ParseTimeBound(crashesSince, now); err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+	} else {
+		end := now
+		if !q.Until.IsZero() {
+			end = q.Until
+		}
+		if q.Since, err = process.ParseTimeBound(crashesLast, end); err != nil {
+			return nil, fmt.Errorf("invalid --last: %w", err)
+		}
+	}
+	if !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return nil, fmt.Errorf("--until is before --since")
+	}
+	return process.QueryCrashReports(q), nil
+}
+
+// crashWindow describes the selected time range, e.g. "in the last 7d".
+func crashWindow() string {
+	switch {
+	case crashesSince != "" && crashesUntil != "":
+		return fmt.Sprintf("between %s and %s", crashesSince, crashesUntil)
+	case crashesSince != "":
+		return "since " + crashesSince
+	case crashesUntil != "":
+		return fmt.Sprintf("in the %s before %s", crashesLast, crashesUntil)
+	default:
+		return "in the last " + crashesLast
+	}
+}
+
+func printNoCrashes() {
+	if crashesProcess != "" {
+		fmt.Printf("No crash reports found for %q %s\n", crashesProcess, crashWindow())
+	} else {
+		fmt.Printf("No crash reports found %s\n", crashWindow())
+	}
+}
+
+// crashStats is the output of crashes stats.
+type crashStats struct {
+	Total     int                `json:"total"`
+	Days      []process.CrashDay `json:"days"`
+	Processes []processCrashes   `json:"processes"`
+}
+
+// processCrashes is the number of crashes of one process.
+type processCrashes struct {
+	Process string `json:"process"`
+	Count   int    `json:"count"`
+}
+
+// topCrashers counts reports per process, most frequent first.
+func topCrashers(reports []process.CrashReport) []processCrashes {
+	counts := make(map[string]int)
+	for _, r := range reports {
+		counts[r.Process]++
+	}
+	result := make([]processCrashes, 0, len(counts))
+	for name, n := range counts {
+		result = append(result, processCrashes{Process: name, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Process < result[j].Process
+	})
+	return result
+}
+
+func printCrashStats(stats crashStats) {
+	maxCount := 0
+	for _, d := range stats.Days {
+		if d.Count > maxCount {
+			maxCount = d.Count
+		}
+	}
+
+	fmt.Printf("Crashes per day (%d total):\n", stats.Total)
+	for _, d := range stats.Days {
+		fmt.Printf("  %s  %-40s %d\n", d.Date, histogramBar(d.Count, maxCount, 40), d.Count)
+	}
+
+	fmt.Println("\nTop crashers:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for i, p := range stats.Processes {
+		if i == 10 {
+			break
+		}
+		fmt.Fprintf(w, "  %s\t%d\n", p.Process, p.Count)
+	}
+	w.Flush()
+}
+
+// histogramBar renders n as a bar scaled so that max fills width.
+func histogramBar(n, max, width int) string {
+	if n <= 0 || max <= 0 {
+		return ""
+	}
+	size := n * width / max
+	if size == 0 {
+		size = 1
+	}
+	return strings.Repeat("█", size)
+}
+
+func printCrashGroups(groups []process.CrashGroup) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "COUNT\tPROCESS\tEXCEPTION\tSIGNAL\tFIRST SEEN\tLAST SEEN\tVERSIONS\tSIGNATURE")
+	for _, g := range groups {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			g.Count, g.Process, g.ExceptType, g.Signal,
+			formatSeen(g.FirstSeen), formatSeen(g.LastSeen),
+			strings.Join(g.Versions, ","), g.Signature)
+	}
+	w.Flush()
+
+	for _, g := range groups {
+		fmt.Printf("\n%s  %s (%d)\n", g.Signature, g.Process, g.Count)
+		for i, frame := range g.TopFrames {
+			fmt.Printf("  %2d: %s\n", i, frame)
+		}
+		fmt.Printf("  sample: %s\n", g.SamplePath)
+	}
+}
+
+// formatSeen formats a first/last seen time, or "-" if unknown.
+func formatSeen(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
 func printCrashTable(reports []process.CrashReport) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 	fmt.Fprintln(w, "TIMESTAMP\tPROCESS\tTYPE\tSIGNAL\tPATH")
 	for _, r := range reports {
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			r.Timestamp, r.Process, r.ReportType, r.Signal, r.Path)
+			r.Timestamp, r.Process, r.ReportType, r.Signal, r.Location())
 	}
 	w.Flush()
 }
@@ -107,6 +592,9 @@ func printCrashDetail(d *process.CrashDetail) {
 	if d.Signal != "" {
 		fmt.Fprintf(w, "Signal:\t%s\n", d.Signal)
 	}
+	if d.Message != "" {
+		fmt.Fprintf(w, "Message:\t%s\n", d.Message)
+	}
 	if d.OSVersion != "" {
 		fmt.Fprintf(w, "OS Version:\t%s\n", d.OSVersion)
 	}
@@ -116,9 +604,25 @@ func printCrashDetail(d *process.CrashDetail) {
 	if d.ReportType == "crash" {
 		fmt.Fprintf(w, "Crash Thread:\t%d\n", d.CrashThread)
 	}
-	fmt.Fprintf(w, "Path:\t%s\n", d.Path)
+	if d.Hang != nil && d.Hang.DurationSampled != "" {
+		fmt.Fprintf(w, "Sampled:\t%s\n", d.Hang.DurationSampled)
+	}
+	if d.Hang != nil && d.Hang.Steps > 0 {
+		fmt.Fprintf(w, "Steps:\t%d\n", d.Hang.Steps)
+	}
+	fmt.Fprintf(w, "Path:\t%s\n", d.Location())
 	w.Flush()
 
+	if d.Hang != nil {
+		printHangInfo(d.Hang, crashesThreads)
+		return
+	}
+
+	if len(d.Threads) > 0 {
+		printCrashThreads(d, crashesThreads)
+		return
+	}
+
 	if len(d.Backtrace) > 0 {
 		fmt.Println("\nBacktrace (faulting thread):")
 		for i, frame := range d.Backtrace {
@@ -126,3 +630,116 @@ func printCrashDetail(d *process.CrashDetail) {
 		}
 	}
 }
+
+// printHangInfo prints where the main thread of a hung process spent its
+// samples, followed by a per-thread summary, or every thread's call tree if
+// all is set.
+func printHangInfo(h *process.HangInfo, all bool) {
+	if len(h.HeaviestStack) > 0 {
+		fmt.Println("\nHeaviest stack (main thread):")
+		for _, n := range h.HeaviestStack {
+			fmt.Printf("  %5d  %s\n", n.Count, hangFrame(n))
+		}
+	}
+	if len(h.Threads) == 0 {
+		return
+	}
+
+	if !all {
+		fmt.Println("\nThreads:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, t := range h.Threads {
+			fmt.Fprintf(w, "  %s\t%s\t%d samples\n", t.ID, t.Queue, t.Samples)
+		}
+		w.Flush()
+		return
+	}
+
+	for _, t := range h.Threads {
+		title := "Thread " + t.ID
+		if t.Queue != "" {
+			title += " [" + t.Queue + "]"
+		}
+		fmt.Printf("\n%s: %d samples\n", title, t.Samples)
+		for _, n := range t.Calls {
+			printCallTree(n, 1)
+		}
+	}
+}
+
+func printCallTree(n *process.CallNode, depth int) {
+	fmt.Printf("%s%d  %s\n", strings.Repeat("  ", depth), n.Count, hangFrame(*n))
+	for _, c := range n.Children {
+		printCallTree(c, depth+1)
+	}
+}
+
+// hangFrame renders a sampled frame, marking kernel frames with "*".
+func hangFrame(n process.CallNode) string {
+	if n.Kernel {
+		return "*" + n.Frame
+	}
+	return n.Frame
+}
+
+// printCrashThreads prints the faulting thread, or every thread if all is
+// set, followed by the binary images referenced by the printed frames.
+func printCrashThreads(d *process.CrashDetail, all bool) {
+	used := make(map[string]bool)
+	for _, t := range d.Threads {
+		if !all && !t.Triggered {
+			continue
+		}
+
+		if all {
+			fmt.Printf("\n%s:\n", threadTitle(t))
+		} else {
+			fmt.Println("\nBacktrace (faulting thread):")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for i, f := range t.Frames {
+			image, addr := "???", ""
+			if f.Image != "" {
+				image = f.Image
+				used[f.Image] = true
+				addr = fmt.Sprintf("0x%x", f.Address)
+			}
+			fmt.Fprintf(w, "  %2d\t%s\t%s\t%s\n", i, image, addr, f.String())
+		}
+		w.Flush()
+	}
+
+	if len(used) == 0 {
+		return
+	}
+	fmt.Println("\nBinary Images:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, img := range d.Images {
+		if !used[img.Name] {
+			continue
+		}
+		uuid := img.UUID
+		if uuid == "" {
+			uuid = "-"
+		}
+		fmt.Fprintf(w, "  0x%x - 0x%x\t%s\t<%s>\t%s\n",
+			img.Base, img.Base+img.Size, img.Name, uuid, img.Path)
+	}
+	w.Flush()
+}
+
+// threadTitle returns a heading such as "Thread 1 (crashed) worker [queue]".
+func threadTitle(t process.CrashThread) string {
+	title := fmt.Sprintf("Thread %d", t.Index)
+	if t.Triggered {
+		title += " (crashed)"
+	}
+	if t.Name != "" {
+		title += " " + t.Name
+	}
+	if t.Queue != "" {
+		title += " [" + t.Queue + "]"
+	}
+	return title
+}
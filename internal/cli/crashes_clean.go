@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+var (
+	cleanOlderThan string
+	cleanArchive   string
+	cleanDryRun    bool
+	cleanYes       bool
+)
+
+var crashesCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Delete or archive old crash reports",
+	Long: `Delete crash reports older than --older-than, or move them into a tar.gz
+archive with --archive. Reports are selected with the same scanning code as
+crashes, so --process and --dir apply; --older-than replaces --last, --since,
+and --until. Reports whose date cannot be read are never removed.
+
+--archive refuses to overwrite an existing file, and reports are deleted only
+after the archive has been completely written to disk.
+
+A summary of the count and size of the selected reports per process is shown
+first, and nothing is removed until you confirm. Use --dry-run to only show
+the summary, or --yes to skip the confirmation (required when stdin is not a
+terminal or with --json).
+
+Reports in system directories such as /Library/Logs/DiagnosticReports or
+/var/crash may need root to remove; files that cannot be removed are listed
+with the reason and the command exits with an error.
+
+Examples:
+  pstop crashes clean --dry-run                  # What would be removed
+  pstop crashes clean --older-than 30d           # Delete after confirmation
+  pstop crashes clean --older-than 2w --process MyApp --yes
+  pstop crashes clean --archive old-crashes.tar.gz`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range []string{"last", "since", "until"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s cannot be used with clean; use --older-than", name)
+			}
+		}
+		before, err := process.ParseTimeBound(cleanOlderThan, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		if jsonFlag && !cleanYes && !cleanDryRun {
+			return fmt.Errorf("--json requires --yes or --dry-run")
+		}
+		if cleanArchive != "" {
+			if err := checkOutputFree("archive", cleanArchive); err != nil {
+				return err
+			}
+		}
+
+		dirs, err := crashDirs()
+		if err != nil {
+			return err
+		}
+		files := process.SelectCrashFiles(dirs, before, crashesProcess)
+		result := cleanResult{
+			DryRun:    cleanDryRun,
+			Archive:   cleanArchive,
+			Selected:  len(files),
+			Processes: process.SummarizeCrashFiles(files),
+			Failed:    []cleanFailure{},
+		}
+
+		if len(files) == 0 {
+			if jsonFlag {
+				return printJSON(result)
+			}
+			if crashesProcess != "" {
+				fmt.Printf("No crash reports for %q older than %s\n", crashesProcess, cleanOlderThan)
+			} else {
+				fmt.Printf("No crash reports older than %s\n", cleanOlderThan)
+			}
+			return nil
+		}
+
+		if !jsonFlag {
+			printCleanSummary(result.Processes)
+		}
+		if cleanDryRun {
+			if jsonFlag {
+				return printJSON(result)
+			}
+			fmt.Println("\nDry run: nothing was removed.")
+			return nil
+		}
+
+		if !cleanYes {
+			if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+				return fmt.Errorf("refusing to remove reports without confirmation; use --yes")
+			}
+			verb := "Delete"
+			if cleanArchive != "" {
+				verb = "Archive to " + cleanArchive + " and delete"
+			}
+			prompt := fmt.Sprintf("\n%s %d report(s)? [y/N] ", verb, len(files))
+			if !confirm(os.Stdin, os.Stdout, prompt) {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if cleanArchive != "" {
+			archived, failed, err := archiveCrashFiles(cleanArchive, files)
+			if err != nil {
+				return err
+			}
+			result.Failed = append(result.Failed, failed...)
+			files = archived
+		}
+		removed, failed := removeCrashFiles(files)
+		result.Failed = append(result.Failed, failed...)
+		for _, f := range removed {
+			result.Removed++
+			result.Freed += f.Size
+		}
+
+		if jsonFlag {
+			if err := printJSON(result); err != nil {
+				return err
+			}
+		} else {
+			printCleanResult(result)
+		}
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("failed to remove %d of %d report(s)", len(result.Failed), result.Selected)
+		}
+		return nil
+	},
+}
+
+func init() {
+	crashesCleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "30d", "Remove reports older than this (e.g., 30d, 2w, or a date)")
+	crashesCleanCmd.Flags().StringVar(&cleanArchive, "archive", "", "Move reports into this tar.gz archive instead of only deleting them")
+	crashesCleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without removing anything")
+	crashesCleanCmd.Flags().BoolVarP(&cleanYes, "yes", "y", false, "Do not ask for confirmation")
+	crashesCmd.AddCommand(crashesCleanCmd)
+}
+
+// cleanResult is the output of crashes clean.
+type cleanResult struct {
+	DryRun    bool                 `json:"dry_run"`
+	Archive   string               `json:"archive,omitempty"`
+	Selected  int                  `json:"selected"`
+	Processes []process.CrashUsage `json:"processes"`
+	Removed   int                  `json:"removed"`
+	Freed     int64                `json:"freed_bytes"`
+	Failed    []cleanFailure       `json:"failed"`
+}
+
+// cleanFailure is a report that could not be archived or removed.
+type cleanFailure struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// newCleanFailure records err for path, without repeating the path that
+// os errors already include.
+func newCleanFailure(path string, err error) cleanFailure {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+	return cleanFailure{Path: path, Error: err.Error()}
+}
+
+// confirm writes prompt to out and reports whether the answer read from in
+// is yes.
+func confirm(in io.Reader, out io.Writer, prompt string) bool {
+	fmt.Fprint(out, prompt)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// removeCrashFiles deletes files, returning those removed and a failure for
+// each one that could not be.
+func removeCrashFiles(files []process.CrashFile) ([]process.CrashFile, []cleanFailure) {
+	var removed []process.CrashFile
+	var failed []cleanFailure
+	for _, f := range files {
+		if err := os.Remove(f.Path); err != nil {
+			failed = append(failed, newCleanFailure(f.Path, err))
+			continue
+		}
+		removed = append(removed, f)
+	}
+	return removed, failed
+}
+
+// checkOutputFree returns an error if out already exists, so that an
+// archive or bundle from an earlier run is never overwritten. kind names
+// the file in the error.
+func checkOutputFree(kind, out string) error {
+	if _, err := os.Lstat(out); err == nil {
+		return fmt.Errorf("%s %s already exists; choose another name", kind, out)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to check %s: %w", out, err)
+	}
+	return nil
+}
+
+// archiveCrashFiles writes files to a tar.gz archive at out under reports/,
+// keeping their modification times. It returns the files that were archived
+// and a failure for each one that could not be read. The error is non-nil
+// only if the archive itself could not be written, in which case the
+// reports must not be removed.
+//
+// The archive is written to a temporary file next to out, synced, and only
+// then linked into place, so that out is either complete or absent. An
+// existing out is never replaced.
+func archiveCrashFiles(out string, files []process.CrashFile) ([]process.CrashFile, []cleanFailure, error) {
+	if err := checkOutputFree("archive", out); err != nil {
+		return nil, nil, err
+	}
+	f, err := createOutput(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	var archived []process.CrashFile
+	var failed []cleanFailure
+	used := make(map[string]bool)
+	for _, cf := range files {
+		data, err := os.ReadFile(cf.Path)
+		if err != nil {
+			failed = append(failed, newCleanFailure(cf.Path, err))
+			continue
+		}
+		modTime := time.Now()
+		if fi, err := os.Stat(cf.Path); err == nil {
+			modTime = fi.ModTime()
+		}
+		name := bundleName(filepath.Base(cf.Path), used)
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+		archived = append(archived, cf)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := commitOutput("archive", f, out); err != nil {
+		return nil, nil, err
+	}
+	return archived, failed, nil
+}
+
+// createOutput creates the temporary file that out is written to, in the
+// same directory. The caller removes it once done; after commitOutput only
+// out remains.
+func createOutput(out string) (*os.File, error) {
+	f, err := os.CreateTemp(filepath.Dir(out), "."+filepath.Base(out)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	return f, nil
+}
+
+// commitOutput syncs and closes f, a file from createOutput, and links it
+// into place as out, so that out is either complete or absent. It fails if
+// out exists by then.
+func commitOutput(kind string, f *os.File, out string) error {
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	// Link fails if out was created in the meantime, unlike Rename.
+	if err := os.Link(f.Name(), out); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%s %s already exists; choose another name", kind, out)
+		}
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	syncDir(filepath.Dir(out))
+	return nil
+}
+
+// syncDir flushes the directory entry of a newly created file to disk. It is
+// best effort: not every platform supports syncing a directory.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// formatBytes formats a size in bytes using the largest fitting binary unit.
+func formatBytes(b int64) string {
+	if b < 1024 {
+		return fmt.Sprintf("%dB", b)
+	}
+	return formatKB(b / 1024)
+}
+
+func printCleanSummary(usage []process.CrashUsage) {
+	var count int
+	var total int64
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROCESS\tREPORTS\tSIZE")
+	for _, u := range usage {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", u.Process, u.Count, formatBytes(u.Bytes))
+		count += u.Count
+		total += u.Bytes
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%s\n", count, formatBytes(total))
+	w.Flush()
+}
+
+func printCleanResult(r cleanResult) {
+	if r.Archive != "" {
+		fmt.Printf("Moved %d report(s) to %s, freed %s\n", r.Removed, r.Archive, formatBytes(r.Freed))
+	} else {
+		fmt.Printf("Removed %d report(s), freed %s\n", r.Removed, formatBytes(r.Freed))
+	}
+	if len(r.Failed) == 0 {
+		return
+	}
+	fmt.Printf("\nCould not remove %d report(s):\n", len(r.Failed))
+	for _, f := range r.Failed {
+		fmt.Printf("  %s: %s\n", f.Path, f.Error)
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if got := confirm(strings.NewReader(tt.input), &out, "Delete? "); got != tt.want {
+			t.Errorf("confirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if out.String() != "Delete? " {
+			t.Errorf("prompt = %q, want %q", out.String(), "Delete? ")
+		}
+	}
+}
+
+func TestRemoveCrashFiles(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "a.ips")
+	if err := os.WriteFile(present, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "gone.ips")
+
+	removed, failed := removeCrashFiles([]process.CrashFile{
+		{CrashReport: process.CrashReport{Path: present}, Size: 2},
+		{CrashReport: process.CrashReport{Path: missing}, Size: 5},
+	})
+	if len(removed) != 1 || removed[0].Path != present {
+		t.Errorf("removed = %+v, want only %s", removed, present)
+	}
+	if _, err := os.Stat(present); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", present)
+	}
+	if len(failed) != 1 || failed[0].Path != missing {
+		t.Fatalf("failed = %+v, want one failure for %s", failed, missing)
+	}
+	if strings.Contains(failed[0].Error, missing) {
+		t.Errorf("failure error %q repeats the path", failed[0].Error)
+	}
+}
+
+func TestArchiveCrashFiles(t *testing.T) {
+	dir := t.TempDir()
+	var files []process.CrashFile
+	for _, sub := range []string{"a", "b"} {
+		p := filepath.Join(dir, sub, "MyApp.ips")
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("report "+sub), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, process.CrashFile{CrashReport: process.CrashReport{Path: p}})
+	}
+	files = append(files, process.CrashFile{CrashReport: process.CrashReport{Path: filepath.Join(dir, "missing.ips")}})
+
+	out := filepath.Join(dir, "old.tar.gz")
+	archived, failed, err := archiveCrashFiles(out, files)
+	if err != nil {
+		t.Fatalf("archiveCrashFiles() error: %v", err)
+	}
+	if len(archived) != 2 || len(failed) != 1 {
+		t.Fatalf("archived %d, failed %d, want 2 and 1", len(archived), len(failed))
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("archive is not gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	contents := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(tr)
+		contents[hdr.Name] = string(data)
+	}
+	if contents["reports/MyApp.ips"] != "report a" || contents["reports/MyApp-2.ips"] != "report b" {
+		t.Errorf("archive contents = %v, want both reports unredacted", contents)
+	}
+}
+
+func TestArchiveCrashFilesKeepsExisting(t *testing.T) {
+	dir := t.TempDir()
+	report := func(name, content string) []process.CrashFile {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return []process.CrashFile{{CrashReport: process.CrashReport{Path: p}}}
+	}
+
+	out := filepath.Join(dir, "old.tar.gz")
+	if _, _, err := archiveCrashFiles(out, report("first.ips", "first run")); err != nil {
+		t.Fatalf("first archiveCrashFiles() error: %v", err)
+	}
+	before, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := report("second.ips", "second run")
+	archived, _, err := archiveCrashFiles(out, second)
+	if err == nil {
+		t.Fatal("second archiveCrashFiles() to the same path should return error")
+	}
+	if len(archived) != 0 {
+		t.Errorf("second run archived %d report(s), want none so that nothing is removed", len(archived))
+	}
+	after, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("second run overwrote the first archive")
+	}
+	if _, err := os.Stat(second[0].Path); err != nil {
+		t.Errorf("second report was touched: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", e.Name())
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{512, "512B"},
+		{2048, "2K"},
+		{5 * 1024 * 1024, "5.0M"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/config"
+	"github.com/lu-zhengda/pstop/internal/process"
+	"github.com/lu-zhengda/pstop/internal/redact"
+)
+
+var (
+	exportOut    string
+	exportRedact []string
+)
+
+var crashesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export crash reports to a redacted tar.gz bundle",
+	Long: `Collect matching crash reports into a tar.gz bundle that is safe to attach
+to a bug report. The bundle contains a redacted copy of each report under
+reports/ and an index.json summary.
+
+Before a report is added, your home directory is replaced with "~", your
+user name, other users' home directories, the host name, and serial numbers
+are replaced with placeholders, and any custom patterns are applied. Custom
+patterns (Go regular expressions) can be given with --redact or in the
+config file:
+  {"crashes": {"redact": ["ACME-[0-9]+"]}}
+
+Core dumps are memory images that cannot be redacted and are never exported.
+Go panics and Python tracebacks found in log files are not exported either,
+since the logs they appear in hold much more than the crash.
+
+The bundle is only written once complete, and an existing file at --out is
+never overwritten.
+
+Accepts the same --last, --since, --until, --process, and --dir flags as
+crashes.
+
+Examples:
+  pstop crashes export --process MyApp --out myapp-crashes.tar.gz
+  pstop crashes export --last 24h --redact 'token=[a-z0-9]+'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFree("bundle", exportOut); err != nil {
+			return err
+		}
+		dirs, err := crashDirs()
+		if err != nil {
+			return err
+		}
+		reports, err := listCrashes(cmd, dirs)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		custom, err := redact.CompileRules(append(cfg.Crashes.Redact, exportRedact...))
+		if err != nil {
+			return err
+		}
+		r := redact.New(append(redact.DefaultRules(redact.CurrentIdentity()), custom...))
+
+		var exportable []process.CrashReport
+		cores, logged := 0, 0
+		for _, rep := range reports {
+			switch {
+			case rep.ReportType == "coredump":
+				cores++
+			case rep.Line > 0:
+				logged++
+			default:
+				exportable = append(exportable, rep)
+			}
+		}
+		if cores > 0 {
+			fmt.Fprintf(os.Stderr, "Skipping %d core dump(s): memory images cannot be redacted.\n", cores)
+		}
+		if logged > 0 {
+			fmt.Fprintf(os.Stderr, "Skipping %d crash(es) found in log files.\n", logged)
+		}
+		if len(exportable) == 0 {
+			printNoCrashes()
+			return nil
+		}
+
+		manifest, err := writeCrashBundle(exportOut, exportable, r)
+		if err != nil {
+			return err
+		}
+
+		if jsonFlag {
+			return printJSON(manifest)
+		}
+		fmt.Printf("Exported %d report(s) to %s\n\n", len(manifest), exportOut)
+		printExportManifest(manifest)
+		return nil
+	},
+}
+
+func init() {
+	crashesExportCmd.Flags().StringVar(&exportOut, "out", "crash-reports.tar.gz", "Path of the bundle to write")
+	crashesExportCmd.Flags().StringArrayVar(&exportRedact, "redact", nil, "Additional regular expression to redact (repeatable)")
+	crashesCmd.AddCommand(crashesExportCmd)
+}
+
+// exportedReport is one entry of a bundle's manifest and index.
+type exportedReport struct {
+	process.CrashReport
+	File       string         `json:"file"`       // path inside the bundle
+	Redactions map[string]int `json:"redactions"` // matches replaced per rule
+}
+
+// bundleIndex is the index.json written into a bundle.
+type bundleIndex struct {
+	Created string           `json:"created"`
+	Reports []exportedReport `json:"reports"`
+}
+
+// writeCrashBundle writes redacted copies of reports and an index.json to a
+// tar.gz archive at out, and returns the manifest of exported reports. Like
+// an archive of crashes clean, the bundle only appears once it is complete,
+// and an existing out is never replaced.
+func writeCrashBundle(out string, reports []process.CrashReport, r *redact.Redactor) ([]exportedReport, error) {
+	if err := checkOutputFree("bundle", out); err != nil {
+		return nil, err
+	}
+	f, err := createOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", name, err)
+		}
+		return nil
+	}
+
+	manifest := make([]exportedReport, 0, len(reports))
+	used := make(map[string]bool)
+	for _, rep := range reports {
+		data, err := os.ReadFile(rep.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", rep.Path, err)
+			continue
+		}
+		data, counts := r.Redact(data)
+
+		entry := exportedReport{
+			CrashReport: rep,
+			File:        bundleName(r.RedactString(filepath.Base(rep.Path)), used),
+			Redactions:  counts,
+		}
+		entry.Path = r.RedactString(rep.Path)
+		if err := add(entry.File, data); err != nil {
+			return nil, err
+		}
+		manifest = append(manifest, entry)
+	}
+
+	index, err := json.MarshalIndent(bundleIndex{Created: now.Format(time.RFC3339), Reports: manifest}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err := add("index.json", index); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := commitOutput("bundle", f, out); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// bundleName returns a unique path under reports/ for a file name, adding a
+// numeric suffix when reports from different directories share a name.
+func bundleName(base string, used map[string]bool) string {
+	name := "reports/" + base
+	ext := filepath.Ext(base)
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("reports/%s-%d%s", strings.TrimSuffix(base, ext), i, ext)
+	}
+	used[name] = true
+	return name
+}
+
+func printExportManifest(manifest []exportedReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tPROCESS\tREDACTED")
+	for _, e := range manifest {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", e.File, e.Process, formatRedactions(e.Redactions))
+	}
+	w.Flush()
+}
+
+// formatRedactions renders per-rule counts as "home=3 user=1", or "-".
+func formatRedactions(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, counts[name])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+	"github.com/lu-zhengda/pstop/internal/redact"
+)
+
+func TestWriteCrashBundle(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a", "MyApp.ips")
+	b := filepath.Join(dir, "b", "MyApp.ips")
+	for _, p := range []string{a, b} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(`{"procPath":"/Users/jdoe/MyApp"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reports := []process.CrashReport{
+		{Process: "MyApp", Path: a, ReportType: "crash"},
+		{Process: "MyApp", Path: b, ReportType: "crash"},
+	}
+	r := redact.New(redact.DefaultRules(redact.Identity{Home: "/Users/jdoe", User: "jdoe"}))
+	out := filepath.Join(dir, "bundle.tar.gz")
+
+	manifest, err := writeCrashBundle(out, reports, r)
+	if err != nil {
+		t.Fatalf("writeCrashBundle() error: %v", err)
+	}
+	if len(manifest) != 2 || manifest[0].File != "reports/MyApp.ips" || manifest[1].File != "reports/MyApp-2.ips" {
+		t.Fatalf("manifest files = %+v, want unique names", manifest)
+	}
+	if manifest[0].Redactions["home"] != 1 {
+		t.Errorf("Redactions = %v, want home=1", manifest[0].Redactions)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("bundle is not gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read bundle: %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(data)
+	}
+
+	if got := files["reports/MyApp.ips"]; got != `{"procPath":"~/MyApp"}` {
+		t.Errorf("exported report = %q, want redacted copy", got)
+	}
+	var index bundleIndex
+	if err := json.Unmarshal([]byte(files["index.json"]), &index); err != nil {
+		t.Fatalf("index.json is invalid: %v", err)
+	}
+	if len(index.Reports) != 2 {
+		t.Errorf("index has %d reports, want 2", len(index.Reports))
+	}
+	if strings.Contains(files["index.json"], "jdoe") {
+		t.Errorf("index.json leaks the user name:\n%s", files["index.json"])
+	}
+}
+
+func TestWriteCrashBundleEscapedPaths(t *testing.T) {
+	dir := t.TempDir()
+	ips := filepath.Join(dir, "MyApp-2026-02-10-143000.ips")
+	content := `{"app_name":"MyApp","procPath":"\/Users\/jdoe\/Applications\/MyApp.app\/Contents\/MacOS\/MyApp"}
+{"usedImages":[{"path":"\/Users\/alice\/Library\/Frameworks\/x.dylib"}]}`
+	if err := os.WriteFile(ips, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := redact.New(redact.DefaultRules(redact.Identity{Home: "/Users/jdoe", User: "jdoe"}))
+	out := filepath.Join(dir, "bundle.tar.gz")
+	if _, err := writeCrashBundle(out, []process.CrashReport{{Process: "MyApp", Path: ips, ReportType: "crash"}}, r); err != nil {
+		t.Fatalf("writeCrashBundle() error: %v", err)
+	}
+
+	files := readBundle(t, out)
+	got := files["reports/MyApp-2026-02-10-143000.ips"]
+	for _, leak := range []string{"jdoe", "alice"} {
+		if strings.Contains(got, leak) {
+			t.Errorf("exported report still contains %q:\n%s", leak, got)
+		}
+	}
+	if !strings.Contains(got, `"procPath":"~\/Applications`) {
+		t.Errorf("exported report = %s, want the escaped home path replaced with ~", got)
+	}
+}
+
+func TestWriteCrashBundleKeepsExisting(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "MyApp.ips")
+	if err := os.WriteFile(report, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "bundle.tar.gz")
+	if err := os.WriteFile(out, []byte("earlier bundle"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := redact.New(nil)
+	if _, err := writeCrashBundle(out, []process.CrashReport{{Process: "MyApp", Path: report}}, r); err == nil {
+		t.Fatal("writeCrashBundle() should refuse to overwrite an existing bundle")
+	}
+	if data, _ := os.ReadFile(out); string(data) != "earlier bundle" {
+		t.Errorf("existing bundle was changed to %q", data)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, ".bundle.tar.gz.*")); len(tmps) != 0 {
+		t.Errorf("temporary files left behind: %v", tmps)
+	}
+}
+
+// readBundle returns the contents of the files in a tar.gz bundle by name.
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("bundle is not gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("failed to read bundle: %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(data)
+	}
+}
+
+func TestFormatRedactions(t *testing.T) {
+	if got := formatRedactions(nil); got != "-" {
+		t.Errorf("formatRedactions(nil) = %q, want -", got)
+	}
+	if got := formatRedactions(map[string]int{"user": 2, "home": 1}); got != "home=1 user=2" {
+		t.Errorf("formatRedactions() = %q, want sorted counts", got)
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestTopCrashers(t *testing.T) {
+	reports := []process.CrashReport{
+		{Process: "b"}, {Process: "a"}, {Process: "b"}, {Process: "c"}, {Process: "a"}, {Process: "b"},
+	}
+	got := topCrashers(reports)
+	want := []processCrashes{{"b", 3}, {"a", 2}, {"c", 1}}
+	if len(got) != len(want) {
+		t.Fatalf("topCrashers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("topCrashers()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHistogramBar(t *testing.T) {
+	tests := []struct {
+		n, max, width int
+		want          int
+	}{
+		{0, 10, 20, 0},
+		{10, 10, 20, 20},
+		{5, 10, 20, 10},
+		{1, 1000, 20, 1}, // never hide a non-zero day
+	}
+	for _, tt := range tests {
+		got := len([]rune(histogramBar(tt.n, tt.max, tt.width)))
+		if got != tt.want {
+			t.Errorf("histogramBar(%d, %d, %d) has %d cells, want %d", tt.n, tt.max, tt.width, got, tt.want)
+		}
+	}
+}
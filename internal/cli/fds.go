@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+var (
+	fdsDuration      time.Duration
+	fdsInterval      time.Duration
+	fdsMinGrowth     float64
+	fdsMinConfidence float64
+	fdsLimitPct      float64
+	fdsProcess       string
+	fdsPID           int
+)
+
+var fdsCmd = &cobra.Command{
+	Use:   "fds",
+	Short: "Detect file descriptor and socket leaks",
+	Long: `Track open file descriptors and TCP connection states per process over
+time. Processes are flagged when their descriptor count climbs steadily
+(--min-growth per minute) or reaches --limit-pct percent of their soft
+open-file limit. CLOSE_WAIT and TIME_WAIT counts are shown for each process,
+and a CLOSE_WAIT count climbing by --min-growth per minute is flagged as
+close-wait-growing, since it usually means sockets are never closed.
+
+The per-process limit is only exposed on Linux. Elsewhere the LIMIT column
+shows "-" and no process is flagged for limit pressure.
+
+For continuous alerting use: pstop watch --alert --fds 80%
+
+Examples:
+  pstop fds                               # Sample for 5 minutes
+  pstop fds --duration 30m --interval 30s
+  pstop fds --process java --limit-pct 50
+  pstop fds --json                        # Include raw samples`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fdsInterval <= 0 || fdsDuration < fdsInterval {
+			return fmt.Errorf("--duration must be at least one --interval")
+		}
+		if cmd.Flags().Changed("limit-pct") && !process.FDLimitsAvailable() {
+			fmt.Fprintln(os.Stderr, "Warning: open-file limits are only available on Linux; --limit-pct has no effect")
+		}
+
+		tracker := process.NewFDTracker()
+		if err := sampleFDs(tracker); err != nil {
+			return err
+		}
+
+		reports := tracker.Report(fdsMinGrowth, fdsMinConfidence, fdsLimitPct)
+		if jsonFlag {
+			if len(reports) == 0 {
+				return printJSON([]process.FDReport{})
+			}
+			return printJSON(reports)
+		}
+
+		if len(reports) == 0 {
+			fmt.Println("No file descriptor growth or limit pressure detected.")
+			return nil
+		}
+		printFDTable(reports)
+		return nil
+	},
+}
+
+func init() {
+	fdsCmd.Flags().DurationVar(&fdsDuration, "duration", 5*time.Minute, "How long to sample")
+	fdsCmd.Flags().DurationVar(&fdsInterval, "interval", 10*time.Second, "Time between samples")
+	fdsCmd.Flags().Float64Var(&fdsMinGrowth, "min-growth", 5, "Minimum descriptor or CLOSE_WAIT growth to flag, per minute")
+	fdsCmd.Flags().Float64Var(&fdsMinConfidence, "min-confidence", 0.6, "Minimum trend confidence to flag growth (0-1)")
+	fdsCmd.Flags().Float64Var(&fdsLimitPct, "limit-pct", 80, "Flag processes using at least this percentage of their open-file limit")
+	fdsCmd.Flags().StringVar(&fdsProcess, "process", "", "Only track processes whose name contains this string")
+	fdsCmd.Flags().IntVar(&fdsPID, "pid", 0, "Only track this PID")
+	rootCmd.AddCommand(fdsCmd)
+}
+
+// sampleFDs feeds descriptor snapshots into tracker until the duration
+// elapses or the user interrupts.
+func sampleFDs(tracker *process.FDTracker) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(fdsInterval)
+	defer ticker.Stop()
+	deadline := time.After(fdsDuration)
+
+	if !jsonFlag {
+		fmt.Fprintf(os.Stderr, "Sampling file descriptors every %s for %s. Press Ctrl+C to stop early.\n",
+			fdsInterval, fdsDuration)
+	}
+
+	sample := func() error {
+		usage, err := process.FDSnapshot()
+		if err != nil {
+			return fmt.Errorf("failed to sample file descriptors: %w", err)
+		}
+		tracker.Add(filterFDUsage(usage), time.Now())
+		return nil
+	}
+
+	if err := sample(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-sigCh:
+			return nil
+		case <-deadline:
+			return sample()
+		case <-ticker.C:
+			if err := sample(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// filterFDUsage applies the --process and --pid filters.
+func filterFDUsage(usage map[int]process.FDUsage) map[int]process.FDUsage {
+	if fdsProcess == "" && fdsPID == 0 {
+		return usage
+	}
+	query := strings.ToLower(fdsProcess)
+	result := make(map[int]process.FDUsage)
+	for pid, u := range usage {
+		if fdsPID != 0 && pid != fdsPID {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(u.Name), query) {
+			continue
+		}
+		result[pid] = u
+	}
+	return result
+}
+
+func printFDTable(reports []process.FDReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tNAME\tFDS\tLIMIT\tUSE%\tGROWTH\tCLOSE_WAIT\tTIME_WAIT\tHISTORY\tFLAGS")
+	for _, r := range reports {
+		values := make([]float64, len(r.Samples))
+		for i, s := range r.Samples {
+			values[i] = float64(s.OpenFiles)
+		}
+		limit := "-"
+		if r.Limit > 0 {
+			limit = fmt.Sprintf("%d", r.Limit)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%.1f\t%+.1f/min\t%d\t%d\t%s\t%s\n",
+			r.PID, r.Name, r.OpenFiles, limit, r.LimitPercent, r.GrowthPerMin,
+			r.States["CLOSE_WAIT"], r.States["TIME_WAIT"],
+			sparkline(values, 30), strings.Join(r.Flags, ","))
+	}
+	w.Flush()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/columns"
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
@@ -14,10 +15,15 @@ var findCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
+		cols, err := tableColumns()
+		if err != nil {
+			return err
+		}
 		procs, err := process.Find(query)
 		if err != nil {
 			return fmt.Errorf("failed to find processes: %w", err)
 		}
+		columns.Fill(procs, cols)
 		if jsonFlag {
 			if len(procs) == 0 {
 				return printJSON([]process.Info{})
@@ -30,7 +36,7 @@ var findCmd = &cobra.Command{
 			return nil
 		}
 
-		printProcessTable(procs)
+		printProcessTable(procs, cols)
 		return nil
 	},
 }
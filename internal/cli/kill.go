@@ -3,8 +3,6 @@ package cli
 import (
 	"fmt"
 	"strconv"
-	"strings"
-	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/lu-zhengda/pstop/internal/process"
@@ -35,7 +33,7 @@ var killCmd = &cobra.Command{
 
 		var signalName string
 		if killSignal != "" {
-			sig, err := parseSignal(killSignal)
+			sig, err := process.ParseSignal(killSignal)
 			if err != nil {
 				return err
 			}
@@ -72,23 +70,3 @@ func init() {
 	killCmd.Flags().StringVar(&killSignal, "signal", "", "Signal to send (e.g., SIGTERM, SIGKILL, SIGHUP)")
 	rootCmd.AddCommand(killCmd)
 }
-
-func parseSignal(s string) (syscall.Signal, error) {
-	s = strings.ToUpper(strings.TrimPrefix(strings.ToUpper(s), "SIG"))
-	signals := map[string]syscall.Signal{
-		"HUP":  syscall.SIGHUP,
-		"INT":  syscall.SIGINT,
-		"QUIT": syscall.SIGQUIT,
-		"KILL": syscall.SIGKILL,
-		"TERM": syscall.SIGTERM,
-		"USR1": syscall.SIGUSR1,
-		"USR2": syscall.SIGUSR2,
-		"STOP": syscall.SIGSTOP,
-		"CONT": syscall.SIGCONT,
-	}
-	sig, ok := signals[s]
-	if !ok {
-		return 0, fmt.Errorf("unknown signal: %s", s)
-	}
-	return sig, nil
-}
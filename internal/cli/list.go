@@ -3,22 +3,38 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/columns"
+	"github.com/lu-zhengda/pstop/internal/config"
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
 var (
-	listSort string
-	listUser string
+	listSort    string
+	listReverse bool
+	listUser    string
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all running processes",
-	Long:  `List all running processes with optional sorting and user filtering.`,
+	Long: `List all running processes with optional sorting and user filtering.
+Sort by any column with --sort; --reverse flips the order.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		sortCol, ok := columns.ByID(listSort)
+		if !ok {
+			return fmt.Errorf("unknown sort column %q (available: %s)",
+				listSort, strings.Join(columns.IDs(columns.All()), ", "))
+		}
+		cols, err := tableColumns()
+		if err != nil {
+			return err
+		}
+
 		procs, err := process.List()
 		if err != nil {
 			return fmt.Errorf("failed to list processes: %w", err)
@@ -34,29 +50,69 @@ var listCmd = &cobra.Command{
 			procs = filtered
 		}
 
-		process.Sort(procs, listSort)
+		columns.Fill(procs, append(cols, sortCol))
+		columns.Sort(procs, sortCol, sortCol.Desc != listReverse)
 
 		if jsonFlag {
 			return printJSON(procs)
 		}
 
-		printProcessTable(procs)
+		printProcessTable(procs, cols)
 		return nil
 	},
 }
 
 func init() {
-	listCmd.Flags().StringVar(&listSort, "sort", "cpu", "Sort by: cpu, mem, pid, name")
+	listCmd.Flags().StringVar(&listSort, "sort", "cpu", "Sort by any column, e.g. cpu, mem, pid, name, rss, elapsed")
+	listCmd.Flags().BoolVar(&listReverse, "reverse", false, "Reverse the sort order")
 	listCmd.Flags().StringVar(&listUser, "user", "", "Filter by user")
 	rootCmd.AddCommand(listCmd)
 }
 
-func printProcessTable(procs []process.Info) {
+// tableColumns returns the columns of process tables: --columns if given,
+// else the config file entry, else the defaults.
+func tableColumns() ([]columns.Column, error) {
+	if columnsFlag != "" {
+		return columns.Parse(columnsFlag)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Columns) > 0 {
+		cols, err := columns.Lookup(cfg.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid columns in config %s: %w", config.Path(), err)
+		}
+		return cols, nil
+	}
+	return columns.Default(), nil
+}
+
+func printProcessTable(procs []process.Info, cols []columns.Column) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-	fmt.Fprintln(w, "PID\tNAME\tUSER\tCPU%\tMEM%\tSTATE\tCOMMAND")
+	fmt.Fprintln(w, columnHeader(cols))
+	now := time.Now()
 	for _, p := range procs {
-		fmt.Fprintf(w, "%d\t%s\t%s\t%.1f\t%.1f\t%s\t%s\n",
-			p.PID, p.Name, p.User, p.CPU, p.Mem, p.State, p.Command)
+		fmt.Fprintln(w, columnRow(p, cols, now))
 	}
 	w.Flush()
 }
+
+// columnHeader returns the tab-separated titles of cols.
+func columnHeader(cols []columns.Column) string {
+	titles := make([]string, len(cols))
+	for i, c := range cols {
+		titles[i] = c.Title
+	}
+	return strings.Join(titles, "\t")
+}
+
+// columnRow returns the tab-separated values of cols for p.
+func columnRow(p process.Info, cols []columns.Column, now time.Time) string {
+	values := make([]string, len(cols))
+	for i, c := range cols {
+		values[i] = c.Value(p, now)
+	}
+	return strings.Join(values, "\t")
+}
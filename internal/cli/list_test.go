@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/columns"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestColumnRow(t *testing.T) {
+	cols, err := columns.Parse("pid,name,rss,tty")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if got := columnHeader(cols); got != "PID\tNAME\tRSS\tTTY" {
+		t.Errorf("columnHeader() = %q", got)
+	}
+	p := process.Info{PID: 42, Name: "node", RSS: 512}
+	if got := columnRow(p, cols, time.Now()); got != "42\tnode\t512K\t-" {
+		t.Errorf("columnRow() = %q", got)
+	}
+}
+
+func TestTableColumnsFlag(t *testing.T) {
+	t.Setenv("PSTOP_CONFIG", "/nonexistent/config.json")
+	columnsFlag = "pid,command"
+	defer func() { columnsFlag = "" }()
+
+	cols, err := tableColumns()
+	if err != nil {
+		t.Fatalf("tableColumns() error: %v", err)
+	}
+	if len(cols) != 2 || cols[1].ID != "command" {
+		t.Errorf("tableColumns() = %v, want pid,command", columns.IDs(cols))
+	}
+}
@@ -21,3 +21,12 @@ func fprintJSON(w io.Writer, v any) error {
 	}
 	return nil
 }
+
+// printJSONLine encodes v as a single line of JSON to stdout, for streaming
+// output such as JSON Lines.
+func printJSONLine(v any) error {
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
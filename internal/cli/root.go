@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -13,7 +14,10 @@ var (
 	// version is set via ldflags at build time.
 	version = "dev"
 
-	jsonFlag bool
+	jsonFlag    bool
+	columnsFlag string
+	tuiInterval time.Duration
+	tuiNoMouse  bool
 )
 
 var rootCmd = &cobra.Command{
@@ -36,8 +40,20 @@ Launch without subcommands for interactive TUI mode.`,
 				return fmt.Errorf("unsupported shell: %s (use bash, zsh, or fish)", shell)
 			}
 		}
-		p := tea.NewProgram(tui.New(version), tea.WithAltScreen())
-		_, err := p.Run()
+		if tuiInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		cols, err := tableColumns()
+		if err != nil {
+			return err
+		}
+		opts := tui.Options{Columns: cols, Interval: tuiInterval}
+		progOpts := []tea.ProgramOption{tea.WithAltScreen()}
+		if !tuiNoMouse {
+			progOpts = append(progOpts, tea.WithMouseCellMotion())
+		}
+		p := tea.NewProgram(tui.New(version, opts), progOpts...)
+		_, err = p.Run()
 		return err
 	},
 }
@@ -52,5 +68,9 @@ func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.Flags().String("generate-completion", "", "Generate shell completion (bash, zsh, fish)")
 	rootCmd.Flags().MarkHidden("generate-completion")
+	rootCmd.Flags().DurationVar(&tuiInterval, "interval", 2*time.Second, "TUI refresh interval (adjust with + and - while running)")
+	rootCmd.Flags().BoolVar(&tuiNoMouse, "no-mouse", false, "Disable mouse capture in the TUI, e.g. to select and copy text")
 	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVar(&columnsFlag, "columns", "",
+		"Comma-separated process table columns (pid, ppid, name, user, cpu, mem, rss, vsz, threads, start, elapsed, tty, nice, ports, files, state, command)")
 }
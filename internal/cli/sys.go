@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// sysSampleInterval is how long CPU usage is measured over.
+const sysSampleInterval = 500 * time.Millisecond
+
+var sysCmd = &cobra.Command{
+	Use:   "sys",
+	Short: "Show system-wide CPU, memory, and load",
+	Long: `Show a summary of the whole system: uptime, load averages, total and
+per-core CPU usage, memory and swap usage, and process and thread counts.
+
+CPU usage is measured over half a second. Per-core usage is read from
+/proc/stat on Linux; on macOS only the total is shown.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sampler := process.NewSysSampler()
+		if _, err := sampler.Sample(); err != nil {
+			return fmt.Errorf("failed to get system stats: %w", err)
+		}
+		time.Sleep(sysSampleInterval)
+		stats, err := sampler.Sample()
+		if err != nil {
+			return fmt.Errorf("failed to get system stats: %w", err)
+		}
+
+		if jsonFlag {
+			return printJSON(stats)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Uptime:\t%s\n", formatUptime(stats.Uptime()))
+		fmt.Fprintf(w, "Load:\t%.2f %.2f %.2f\n", stats.Load[0], stats.Load[1], stats.Load[2])
+		fmt.Fprintf(w, "CPU:\t%.1f%%\n", stats.CPU)
+		if len(stats.Cores) > 0 {
+			cores := make([]string, len(stats.Cores))
+			for i, c := range stats.Cores {
+				cores[i] = fmt.Sprintf("%.0f%%", c)
+			}
+			fmt.Fprintf(w, "Cores:\t%s\n", strings.Join(cores, " "))
+		}
+		fmt.Fprintf(w, "Memory:\t%s used of %s (%.1f%%), %s free\n",
+			formatKB(stats.MemUsed), formatKB(stats.MemTotal), stats.MemPercent(), formatKB(stats.MemFree))
+		if stats.SwapTotal > 0 {
+			fmt.Fprintf(w, "Swap:\t%s used of %s (%.1f%%)\n",
+				formatKB(stats.SwapUsed), formatKB(stats.SwapTotal), stats.SwapPercent())
+		} else {
+			fmt.Fprintf(w, "Swap:\tnone\n")
+		}
+		fmt.Fprintf(w, "Processes:\t%d (%d threads)\n", stats.Processes, stats.Threads)
+		w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sysCmd)
+}
+
+// formatUptime formats a duration as days, hours, and minutes.
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	mins := int(d % time.Hour / time.Minute)
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, mins)
+	}
+	return fmt.Sprintf("%dh %dm", hours, mins)
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0h 0m"},
+		{42 * time.Minute, "0h 42m"},
+		{5*time.Hour + 59*time.Minute + 40*time.Second, "6h 0m"},
+		{3*24*time.Hour + 4*time.Hour + 12*time.Minute, "3d 4h 12m"},
+	}
+	for _, tt := range tests {
+		if got := formatUptime(tt.d); got != tt.want {
+			t.Errorf("formatUptime(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
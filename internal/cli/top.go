@@ -3,9 +3,12 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/lu-zhengda/pstop/internal/columns"
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
@@ -30,6 +33,43 @@ func spark(pct float64) string {
 	return string(sparkChars[idx])
 }
 
+// sparkline renders values as a sparkline scaled between their minimum and
+// maximum. Series longer than width are downsampled by averaging.
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if width > 0 && len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			lo := i * len(values) / width
+			hi := (i + 1) * len(values) / width
+			sum := 0.0
+			for _, v := range values[lo:hi] {
+				sum += v
+			}
+			buckets[i] = sum / float64(hi-lo)
+		}
+		values = buckets
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		pct := 0.0
+		if hi > lo {
+			pct = (v - lo) / (hi - lo) * 100
+		}
+		b.WriteString(spark(pct))
+	}
+	return b.String()
+}
+
 var topCmd = &cobra.Command{
 	Use:   "top",
 	Short: "Show top resource-consuming processes",
@@ -41,6 +81,11 @@ Use --format spark to show inline sparkline bars for CPU and memory.`,
 		if err != nil {
 			return fmt.Errorf("failed to get top processes: %w", err)
 		}
+		cols, err := tableColumns()
+		if err != nil {
+			return err
+		}
+		columns.Fill(procs, cols)
 
 		if jsonFlag {
 			return printJSON(procs)
@@ -51,14 +96,14 @@ Use --format spark to show inline sparkline bars for CPU and memory.`,
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-		fmt.Fprintln(w, "PID\tNAME\tUSER\tCPU%\tMEM%\tSTATE\tCOMMAND")
+		fmt.Fprintln(w, columnHeader(cols))
+		now := time.Now()
 		for _, p := range procs {
 			prefix := ""
 			if topBattery && p.CPU > 10.0 {
 				prefix = "!! "
 			}
-			fmt.Fprintf(w, "%s%d\t%s\t%s\t%.1f\t%.1f\t%s\t%s\n",
-				prefix, p.PID, p.Name, p.User, p.CPU, p.Mem, p.State, p.Command)
+			fmt.Fprintln(w, prefix+columnRow(p, cols, now))
 		}
 		w.Flush()
 		return nil
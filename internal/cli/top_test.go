@@ -39,3 +39,25 @@ func TestSparkMonotonic(t *testing.T) {
 		prev = curr
 	}
 }
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 10, ""},
+		{"flat", []float64{5, 5, 5}, 10, "▁▁▁"},
+		{"rising", []float64{0, 50, 100}, 10, "▁▄█"},
+		{"downsampled", []float64{0, 0, 100, 100}, 2, "▁█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"text/tabwriter"
 	"time"
@@ -14,19 +16,45 @@ import (
 )
 
 var (
-	watchInterval int
-	watchAlert    bool
-	watchCPU      float64
-	watchMem      float64
+	watchInterval   int
+	watchAlert      bool
+	watchCPU        float64
+	watchMem        float64
+	watchFDs        string
+	watchContinuous bool
+	watchRenotify   time.Duration
+	watchOnAlert    []string
+	watchCooldown   time.Duration
+	watchDryRun     bool
+	watchCrashLoop  int
+	watchCrashWin   time.Duration
+
+	watchWebhook         string
+	watchWebhookHeaders  []string
+	watchWebhookTemplate string
+	watchWebhookTimeout  time.Duration
+	watchWebhookRetries  int
+
+	// fdLimit and fdPercent hold the parsed --fds threshold.
+	fdLimit   float64
+	fdPercent bool
+)
+
+// Alert states reported in continuous mode.
+const (
+	alertFiring   = "firing"
+	alertResolved = "resolved"
 )
 
 // Alert holds information about a threshold violation.
 type Alert struct {
-	Timestamp string       `json:"timestamp"`
-	Threshold string       `json:"threshold"`
-	Value     float64      `json:"value"`
-	Limit     float64      `json:"limit"`
-	Process   process.Info `json:"process"`
+	Timestamp string         `json:"timestamp"`
+	State     string         `json:"state,omitempty"` // firing or resolved (continuous mode only)
+	Threshold string         `json:"threshold"`
+	Value     float64        `json:"value"`
+	Limit     float64        `json:"limit"`
+	Process   process.Info   `json:"process"`
+	Actions   []ActionResult `json:"actions,omitempty"`
 }
 
 var watchCmd = &cobra.Command{
@@ -35,13 +63,49 @@ var watchCmd = &cobra.Command{
 	Long: `Watch a process in real-time, refreshing at the specified interval.
 
 With --alert, monitor all processes and exit with code 1 when any process
-exceeds the specified CPU or memory threshold.
+exceeds the specified CPU, memory, or file descriptor threshold. --fds takes
+either a percentage of the process's soft open-file limit (e.g. 80%; Linux
+only, since other systems do not expose the limit) or an absolute descriptor
+count (e.g. 5000). --crash-loop N alerts on a running
+process that has crashed at least N times within --crash-window and was
+restarted since (see crashes --live).
+
+With --alert --continuous, keep running instead: report one alert per
+offending process when it first crosses a threshold, repeat it every
+--renotify interval while it stays above, and report a "resolved" event
+once it drops back below. With --json each event is a single JSON line.
+
+With --on-alert, run an action for every firing alert and record its result
+in the alert output. In continuous mode actions run in the background, so a
+slow one delays only the report of its own alert, never the checks:
+  exec:<cmd>     run <cmd> via sh with the alert JSON on stdin and PID, NAME,
+                 VALUE, THRESHOLD, and LIMIT set in the environment
+  kill           send SIGTERM to the offending process
+  signal:<name>  send the named signal (e.g. signal:USR1)
+Each action runs at most once per --action-cooldown for a given process.
+Use --dry-run to report what would be done without doing it.
+
+With --webhook, POST every alert (and, in continuous mode, every resolved
+event) to an HTTP endpoint as JSON. Alerts are delivered in the background,
+so a slow endpoint never delays the checks; delivery is retried with
+exponential backoff and failures are reported on stderr. On exit, pending
+deliveries get up to 10 seconds to finish.
+--webhook-template renders the body with Go text/template syntax against the
+alert (e.g. '{"text": "{{.Process.Name}} {{.Threshold}} {{.Value}}"}'),
+or "@file" to read the template from a file. The "json" function encodes
+any value as JSON.
 
 Examples:
   pstop watch 1234                        # Watch a single process
   pstop watch --alert --cpu 80            # Alert when any process exceeds 80% CPU
   pstop watch --alert --cpu 80 --mem 90   # Alert on CPU > 80% or memory > 90%
-  pstop watch --alert --mem 50 --json     # Output structured alert data`,
+  pstop watch --alert --mem 50 --json     # Output structured alert data
+  pstop watch --alert --fds 80%           # Alert near the open-file limit
+  pstop watch --alert --crash-loop 3 --crash-window 15m
+  pstop watch --alert --cpu 80 --continuous --renotify 10m
+  pstop watch --alert --mem 30 --on-alert signal:USR1 --dry-run
+  pstop watch --alert --cpu 95 --continuous --on-alert 'exec:notify.sh'
+  pstop watch --alert --cpu 90 --continuous --webhook http://localhost:9000/alerts`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if watchAlert {
@@ -74,6 +138,19 @@ func init() {
 	watchCmd.Flags().BoolVar(&watchAlert, "alert", false, "Monitor all processes for threshold violations")
 	watchCmd.Flags().Float64Var(&watchCPU, "cpu", 0, "CPU threshold percentage (used with --alert)")
 	watchCmd.Flags().Float64Var(&watchMem, "mem", 0, "Memory threshold percentage (used with --alert)")
+	watchCmd.Flags().StringVar(&watchFDs, "fds", "", "Open file descriptor threshold, as a percentage of the limit (80%, Linux only) or a count (used with --alert)")
+	watchCmd.Flags().IntVar(&watchCrashLoop, "crash-loop", 0, "Alert on a restarted process with at least this many crashes within --crash-window (used with --alert)")
+	watchCmd.Flags().DurationVar(&watchCrashWin, "crash-window", 10*time.Minute, "Time window for counting crashes (used with --crash-loop)")
+	watchCmd.Flags().BoolVar(&watchContinuous, "continuous", false, "Keep running and report alerts as they fire and resolve (used with --alert)")
+	watchCmd.Flags().DurationVar(&watchRenotify, "renotify", 5*time.Minute, "Repeat a still-firing alert after this interval, 0 to disable (used with --continuous)")
+	watchCmd.Flags().StringArrayVar(&watchOnAlert, "on-alert", nil, "Action to run when an alert fires: exec:<cmd>, kill, or signal:<name> (repeatable)")
+	watchCmd.Flags().DurationVar(&watchCooldown, "action-cooldown", time.Minute, "Minimum time between runs of the same action for one process")
+	watchCmd.Flags().BoolVar(&watchDryRun, "dry-run", false, "Report --on-alert actions without running them")
+	watchCmd.Flags().StringVar(&watchWebhook, "webhook", "", "POST alerts as JSON to this URL")
+	watchCmd.Flags().StringArrayVar(&watchWebhookHeaders, "webhook-header", nil, "Extra webhook request header as \"Key: Value\" (repeatable)")
+	watchCmd.Flags().StringVar(&watchWebhookTemplate, "webhook-template", "", "Go template for the webhook body, or @file")
+	watchCmd.Flags().DurationVar(&watchWebhookTimeout, "webhook-timeout", 5*time.Second, "Timeout for each webhook request")
+	watchCmd.Flags().IntVar(&watchWebhookRetries, "webhook-retries", 3, "Number of webhook retries after a failed delivery")
 	rootCmd.AddCommand(watchCmd)
 }
 
@@ -108,10 +185,48 @@ func runWatchPID(pid int) error {
 	}
 }
 
-// runAlertMode monitors all processes and exits when a threshold is exceeded.
+// runAlertMode monitors all processes for threshold violations. By default
+// it exits on the first violation; with --continuous it keeps running and
+// reports each alert as it fires and resolves.
 func runAlertMode() error {
-	if watchCPU <= 0 && watchMem <= 0 {
-		return fmt.Errorf("--alert requires at least one of --cpu or --mem to be set")
+	var err error
+	fdLimit, fdPercent, err = parseFDThreshold(watchFDs)
+	if err != nil {
+		return err
+	}
+	if watchCPU <= 0 && watchMem <= 0 && fdLimit <= 0 && watchCrashLoop <= 0 {
+		return fmt.Errorf("--alert requires at least one of --cpu, --mem, --fds, or --crash-loop to be set")
+	}
+	if fdPercent && !process.FDLimitsAvailable() {
+		return fmt.Errorf("--fds %s needs per-process open-file limits, which are only available on Linux; give a descriptor count instead (e.g. --fds 5000)", watchFDs)
+	}
+	if watchCrashLoop > 0 && watchCrashWin <= 0 {
+		return fmt.Errorf("--crash-window must be positive")
+	}
+
+	actions, err := newActionRunner(watchOnAlert, watchCooldown, watchDryRun)
+	if err != nil {
+		return err
+	}
+
+	var webhook *webhookNotifier
+	if watchWebhook != "" {
+		webhook, err = newWebhookNotifier(watchWebhook, watchWebhookHeaders, watchWebhookTemplate,
+			watchWebhookTimeout, watchWebhookRetries)
+		if err != nil {
+			return err
+		}
+		webhook.start()
+		defer func() {
+			if !webhook.stop(webhookDrainTimeout) {
+				fmt.Fprintf(os.Stderr, "Warning: gave up on undelivered webhook alerts after %s\n", webhookDrainTimeout)
+			}
+		}()
+	}
+	notify := func(alert Alert) {
+		if webhook != nil {
+			webhook.enqueue(alert)
+		}
 	}
 
 	sigCh := make(chan os.Signal, 1)
@@ -131,57 +246,392 @@ func runAlertMode() error {
 			}
 			thresholds += fmt.Sprintf("MEM > %.1f%%", watchMem)
 		}
+		if fdLimit > 0 {
+			if thresholds != "" {
+				thresholds += ", "
+			}
+			if fdPercent {
+				thresholds += fmt.Sprintf("FDS > %.1f%% of limit", fdLimit)
+			} else {
+				thresholds += fmt.Sprintf("FDS > %.0f", fdLimit)
+			}
+		}
+		if watchCrashLoop > 0 {
+			if thresholds != "" {
+				thresholds += ", "
+			}
+			thresholds += fmt.Sprintf("CRASHES >= %d in %s", watchCrashLoop, watchCrashWin)
+		}
 		fmt.Printf("Watching for alerts (%s, interval: %ds). Press Ctrl+C to stop.\n", thresholds, watchInterval)
 	}
 
+	tracker := newAlertTracker(watchRenotify)
+
+	// In continuous mode events are handled on a queue, so that slow
+	// actions never delay the next sample.
+	var events *eventQueue
+	stopEvents := func() {
+		if events != nil && !events.stop(eventDrainTimeout) {
+			fmt.Fprintf(os.Stderr, "Warning: gave up on pending alert events after %s\n", eventDrainTimeout)
+		}
+		events = nil
+	}
+	var eventErrs <-chan error
+	if watchContinuous {
+		events = startEventQueue(func(alert Alert, at time.Time) error {
+			if alert.State == alertFiring {
+				alert.Actions = actions.run(alert, at)
+			}
+			notify(alert)
+			return reportAlertEvent(alert)
+		})
+		eventErrs = events.errs
+		defer stopEvents()
+	}
+
+	// check evaluates one sample. It returns done=true when the watcher
+	// should stop (one-shot mode after the first violation, where the
+	// actions run before the alert is reported and the watcher exits).
+	check := func() (bool, error) {
+		s, err := takeAlertSample()
+		if err != nil {
+			return false, nil
+		}
+		if !watchContinuous {
+			if alerts := evaluateThresholds(s); len(alerts) > 0 {
+				alert := alerts[0]
+				alert.Actions = actions.run(alert, s.time)
+				notify(alert)
+				return true, reportAlert(&alert)
+			}
+			return false, nil
+		}
+		for _, alert := range tracker.update(s) {
+			events.add(alert, s.time)
+		}
+		return false, nil
+	}
+
 	// Check immediately, then on each tick.
-	if alert := checkThresholds(); alert != nil {
-		return reportAlert(alert)
+	if done, err := check(); done {
+		return err
 	}
 
 	for {
 		select {
 		case <-sigCh:
+			// Report the pending events before saying goodbye.
+			stopEvents()
 			if !jsonFlag {
 				fmt.Println("\nStopped watching.")
 			}
 			return nil
+		case err := <-eventErrs:
+			return err
 		case <-ticker.C:
-			if alert := checkThresholds(); alert != nil {
-				return reportAlert(alert)
+			if done, err := check(); done {
+				return err
 			}
 		}
 	}
 }
 
-// checkThresholds scans all processes and returns an Alert if any exceed thresholds.
-func checkThresholds() *Alert {
+// alertSample is a single observation of the system that thresholds are
+// evaluated against.
+type alertSample struct {
+	time  time.Time
+	procs []process.Info
+	fds   map[int]process.FDUsage   // nil unless --fds is set
+	loops map[int]process.CrashLoop // by PID, nil unless --crash-loop is set
+}
+
+// takeAlertSample collects the data needed to evaluate thresholds.
+func takeAlertSample() (alertSample, error) {
 	procs, err := process.List()
 	if err != nil {
-		return nil
+		return alertSample{}, err
+	}
+	s := alertSample{time: time.Now(), procs: procs}
+	if fdLimit > 0 {
+		if s.fds, err = process.FDSnapshot(); err != nil {
+			return alertSample{}, err
+		}
 	}
+	if watchCrashLoop > 0 {
+		dirs, err := crashDirs()
+		if err != nil {
+			return alertSample{}, err
+		}
+		logs, err := crashLogs()
+		if err != nil {
+			return alertSample{}, err
+		}
+		reports := process.QueryCrashReports(process.CrashQuery{Dirs: dirs, Logs: logs, Since: s.time.Add(-watchCrashWin)})
+		s.loops = make(map[int]process.CrashLoop)
+		for _, l := range process.DetectCrashLoops(reports, procs, process.StartTime, watchCrashLoop, watchCrashWin, s.time) {
+			s.loops[l.PID] = l
+		}
+	}
+	return s, nil
+}
+
+// value returns the current value of the given threshold metric for pid.
+// The second result is false if the process is no longer present.
+func (s alertSample) value(pid int, threshold string) (float64, bool) {
+	if threshold == "fds" || threshold == "fds_count" {
+		u, ok := s.fds[pid]
+		if !ok {
+			return 0, false
+		}
+		if threshold == "fds" {
+			return u.LimitPercent(), true
+		}
+		return float64(u.OpenFiles), true
+	}
+	if threshold == "crash_loop" {
+		l, ok := s.loops[pid]
+		if !ok {
+			// The process is still running but no longer crash-looping.
+			return 0, s.loops != nil
+		}
+		return float64(l.Crashes), true
+	}
+	for _, p := range s.procs {
+		if p.PID != pid {
+			continue
+		}
+		switch threshold {
+		case "cpu":
+			return p.CPU, true
+		case "mem":
+			return p.Mem, true
+		}
+	}
+	return 0, false
+}
 
-	for _, p := range procs {
+// evaluateThresholds returns one Alert per process and threshold exceeded in s.
+func evaluateThresholds(s alertSample) []Alert {
+	ts := s.time.Format(time.RFC3339)
+	var alerts []Alert
+	for _, p := range s.procs {
 		if watchCPU > 0 && p.CPU > watchCPU {
-			return &Alert{
-				Timestamp: time.Now().Format(time.RFC3339),
+			alerts = append(alerts, Alert{
+				Timestamp: ts,
 				Threshold: "cpu",
 				Value:     p.CPU,
 				Limit:     watchCPU,
 				Process:   p,
-			}
+			})
 		}
 		if watchMem > 0 && p.Mem > watchMem {
-			return &Alert{
-				Timestamp: time.Now().Format(time.RFC3339),
+			alerts = append(alerts, Alert{
+				Timestamp: ts,
 				Threshold: "mem",
 				Value:     p.Mem,
 				Limit:     watchMem,
 				Process:   p,
+			})
+		}
+		if u, ok := s.fds[p.PID]; ok && fdLimit > 0 {
+			switch {
+			case fdPercent && u.Limit > 0 && u.LimitPercent() > fdLimit:
+				alerts = append(alerts, Alert{
+					Timestamp: ts,
+					Threshold: "fds",
+					Value:     u.LimitPercent(),
+					Limit:     fdLimit,
+					Process:   p,
+				})
+			case !fdPercent && float64(u.OpenFiles) > fdLimit:
+				alerts = append(alerts, Alert{
+					Timestamp: ts,
+					Threshold: "fds_count",
+					Value:     float64(u.OpenFiles),
+					Limit:     fdLimit,
+					Process:   p,
+				})
 			}
 		}
+		if l, ok := s.loops[p.PID]; ok {
+			alerts = append(alerts, Alert{
+				Timestamp: ts,
+				Threshold: "crash_loop",
+				Value:     float64(l.Crashes),
+				Limit:     float64(watchCrashLoop),
+				Process:   p,
+			})
+		}
+	}
+	return alerts
+}
+
+// parseFDThreshold parses a --fds value: "80%" is a percentage of the
+// process's open-file limit, "5000" an absolute descriptor count. An empty
+// value disables the threshold.
+func parseFDThreshold(s string) (float64, bool, error) {
+	if s == "" {
+		return 0, false, nil
+	}
+	num, percent := strings.CutSuffix(strings.TrimSpace(s), "%")
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || v <= 0 {
+		return 0, false, fmt.Errorf("invalid --fds threshold %q (use e.g. 80%% or 5000)", s)
+	}
+	if percent && v > 100 {
+		return 0, false, fmt.Errorf("invalid --fds threshold %q: percentage must not exceed 100", s)
+	}
+	return v, percent, nil
+}
+
+// formatAlertValue formats v in the unit of the given threshold.
+func formatAlertValue(threshold string, v float64) string {
+	if threshold == "fds_count" || threshold == "crash_loop" {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f%%", v)
+}
+
+// alertKey identifies an alert by process and threshold for de-duplication.
+type alertKey struct {
+	pid       int
+	threshold string
+}
+
+// trackedAlert is an alert that is currently firing.
+type trackedAlert struct {
+	alert        Alert
+	lastNotified time.Time
+}
+
+// alertTracker de-duplicates alerts across samples in continuous mode.
+type alertTracker struct {
+	renotify time.Duration
+	active   map[alertKey]*trackedAlert
+}
+
+func newAlertTracker(renotify time.Duration) *alertTracker {
+	return &alertTracker{
+		renotify: renotify,
+		active:   make(map[alertKey]*trackedAlert),
+	}
+}
+
+// update evaluates s and returns the events to report: newly firing alerts,
+// alerts due for re-notification, and alerts that have resolved since the
+// previous sample. A renotify interval of zero disables re-notification.
+func (t *alertTracker) update(s alertSample) []Alert {
+	var events []Alert
+	seen := make(map[alertKey]bool)
+
+	for _, alert := range evaluateThresholds(s) {
+		key := alertKey{pid: alert.Process.PID, threshold: alert.Threshold}
+		seen[key] = true
+		alert.State = alertFiring
+
+		tracked, ok := t.active[key]
+		if !ok {
+			t.active[key] = &trackedAlert{alert: alert, lastNotified: s.time}
+			events = append(events, alert)
+			continue
+		}
+		tracked.alert = alert
+		if t.renotify > 0 && s.time.Sub(tracked.lastNotified) >= t.renotify {
+			tracked.lastNotified = s.time
+			events = append(events, alert)
+		}
+	}
+
+	for key, tracked := range t.active {
+		if seen[key] {
+			continue
+		}
+		resolved := tracked.alert
+		resolved.State = alertResolved
+		resolved.Timestamp = s.time.Format(time.RFC3339)
+		resolved.Value = 0
+		if v, ok := s.value(key.pid, key.threshold); ok {
+			resolved.Value = v
+		}
+		events = append(events, resolved)
+		delete(t.active, key)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Process.PID != events[j].Process.PID {
+			return events[i].Process.PID < events[j].Process.PID
+		}
+		return events[i].Threshold < events[j].Threshold
+	})
+	return events
+}
+
+// eventQueueSize is the number of continuous-mode events that can wait to be
+// handled before new ones are dropped.
+const eventQueueSize = 256
+
+// eventDrainTimeout bounds how long the watcher waits on exit for queued
+// events to be handled.
+const eventDrainTimeout = 10 * time.Second
+
+// alertEvent is a continuous-mode event and the time of its sample.
+type alertEvent struct {
+	alert Alert
+	at    time.Time
+}
+
+// eventQueue handles continuous-mode events in order on a goroutine: it runs
+// the --on-alert actions of firing alerts, then notifies and reports each
+// event. An --on-alert hook may run for up to actionTimeout, which would
+// otherwise hold up sampling for every watched process.
+type eventQueue struct {
+	queue chan alertEvent
+	done  chan struct{} // closed when the queue is drained
+	errs  chan error    // the first error handling an event
+}
+
+// startEventQueue starts handling events with handle.
+func startEventQueue(handle func(alert Alert, at time.Time) error) *eventQueue {
+	q := &eventQueue{
+		queue: make(chan alertEvent, eventQueueSize),
+		done:  make(chan struct{}),
+		errs:  make(chan error, 1),
+	}
+	go func() {
+		defer close(q.done)
+		for e := range q.queue {
+			if err := handle(e.alert, e.at); err != nil {
+				select {
+				case q.errs <- err:
+				default:
+				}
+			}
+		}
+	}()
+	return q
+}
+
+// add queues an event without waiting. If the queue is full the event is
+// dropped with a warning.
+func (q *eventQueue) add(alert Alert, at time.Time) {
+	select {
+	case q.queue <- alertEvent{alert: alert, at: at}:
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: alert queue full, dropping %s %s event for PID %d\n",
+			alert.Threshold, alert.State, alert.Process.PID)
+	}
+}
+
+// stop waits up to timeout for the queued events to be handled. It reports
+// whether they all were; any left are abandoned.
+func (q *eventQueue) stop(timeout time.Duration) bool {
+	close(q.queue)
+	select {
+	case <-q.done:
+		return true
+	case <-time.After(timeout):
+		return false
 	}
-	return nil
 }
 
 // reportAlert outputs the alert and returns an error to trigger exit code 1.
@@ -195,11 +645,57 @@ func reportAlert(alert *Alert) error {
 
 	fmt.Printf("\nALERT: %s threshold exceeded!\n", alert.Threshold)
 	fmt.Printf("  Process: %s (PID %d)\n", alert.Process.Name, alert.Process.PID)
-	fmt.Printf("  %s: %.1f%% (limit: %.1f%%)\n", alert.Threshold, alert.Value, alert.Limit)
+	fmt.Printf("  %s: %s (limit: %s)\n", alert.Threshold,
+		formatAlertValue(alert.Threshold, alert.Value), formatAlertValue(alert.Threshold, alert.Limit))
 	fmt.Printf("  Time: %s\n", alert.Timestamp)
+	printActionResults(alert.Actions)
 	return fmt.Errorf("threshold exceeded")
 }
 
+// reportAlertEvent outputs a single continuous-mode event as one line of
+// text or one JSON Line.
+func reportAlertEvent(alert Alert) error {
+	if jsonFlag {
+		if err := printJSONLine(alert); err != nil {
+			return fmt.Errorf("failed to output alert: %w", err)
+		}
+		return nil
+	}
+
+	if alert.State == alertResolved {
+		fmt.Printf("%s RESOLVED %s: %s (PID %d) %s (limit: %s)\n",
+			alert.Timestamp, alert.Threshold, alert.Process.Name, alert.Process.PID,
+			formatAlertValue(alert.Threshold, alert.Value), formatAlertValue(alert.Threshold, alert.Limit))
+		return nil
+	}
+	fmt.Printf("%s ALERT %s: %s (PID %d) %s (limit: %s)\n",
+		alert.Timestamp, alert.Threshold, alert.Process.Name, alert.Process.PID,
+		formatAlertValue(alert.Threshold, alert.Value), formatAlertValue(alert.Threshold, alert.Limit))
+	printActionResults(alert.Actions)
+	return nil
+}
+
+// printActionResults prints one line per --on-alert action result.
+func printActionResults(results []ActionResult) {
+	for _, r := range results {
+		switch {
+		case r.Skipped != "":
+			fmt.Printf("  Action %s: skipped, %s\n", r.Action, r.Skipped)
+		case r.DryRun:
+			fmt.Printf("  Action %s: dry run, %s\n", r.Action, r.Output)
+		case r.OK:
+			fmt.Printf("  Action %s: ok\n", r.Action)
+		default:
+			fmt.Printf("  Action %s: failed: %s\n", r.Action, r.Error)
+		}
+		if !r.DryRun && r.Output != "" {
+			for _, line := range strings.Split(r.Output, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+}
+
 func printWatchInfo(pid int) error {
 	info, err := process.GetInfo(pid)
 	if err != nil {
@@ -1,26 +1,33 @@
 package cli
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
-func TestCheckThresholdsNone(t *testing.T) {
-	// With thresholds at 0, checkThresholds should return nil (no alert).
-	origCPU := watchCPU
-	origMem := watchMem
+func TestEvaluateThresholdsNone(t *testing.T) {
+	// With thresholds at 0, evaluateThresholds should return no alerts.
+	origCPU, origMem, origLimit, origLoop := watchCPU, watchMem, fdLimit, watchCrashLoop
 	defer func() {
-		watchCPU = origCPU
-		watchMem = origMem
+		watchCPU, watchMem, fdLimit, watchCrashLoop = origCPU, origMem, origLimit, origLoop
 	}()
+	watchCPU, watchMem, fdLimit, watchCrashLoop = 0, 0, 0, 0
 
-	watchCPU = 0
-	watchMem = 0
-
-	alert := checkThresholds()
-	if alert != nil {
-		t.Errorf("checkThresholds() with zero thresholds should return nil, got %+v", alert)
+	s := alertSample{
+		time: time.Now(),
+		procs: []process.Info{
+			{PID: 1, Name: "busy", CPU: 99, Mem: 90},
+		},
+		fds: map[int]process.FDUsage{
+			1: {PID: 1, OpenFiles: 1000, Limit: 1000},
+		},
+	}
+	if alerts := evaluateThresholds(s); len(alerts) != 0 {
+		t.Errorf("evaluateThresholds() with zero thresholds = %+v, want none", alerts)
 	}
 }
 
@@ -50,3 +57,261 @@ func TestAlertStruct(t *testing.T) {
 		t.Errorf("Alert.Process.PID = %d, want 123", alert.Process.PID)
 	}
 }
+
+func TestEvaluateThresholdsAllOffenders(t *testing.T) {
+	origCPU, origMem := watchCPU, watchMem
+	defer func() {
+		watchCPU = origCPU
+		watchMem = origMem
+	}()
+	watchCPU = 50
+	watchMem = 10
+
+	s := alertSample{
+		time: time.Now(),
+		procs: []process.Info{
+			{PID: 1, Name: "idle", CPU: 1, Mem: 1},
+			{PID: 2, Name: "busy", CPU: 90, Mem: 1},
+			{PID: 3, Name: "both", CPU: 60, Mem: 20},
+		},
+	}
+
+	alerts := evaluateThresholds(s)
+	if len(alerts) != 3 {
+		t.Fatalf("evaluateThresholds() returned %d alerts, want 3", len(alerts))
+	}
+	if alerts[0].Process.PID != 2 || alerts[0].Threshold != "cpu" {
+		t.Errorf("alerts[0] = PID %d %s, want PID 2 cpu", alerts[0].Process.PID, alerts[0].Threshold)
+	}
+	if alerts[2].Process.PID != 3 || alerts[2].Threshold != "mem" {
+		t.Errorf("alerts[2] = PID %d %s, want PID 3 mem", alerts[2].Process.PID, alerts[2].Threshold)
+	}
+}
+
+func TestAlertTrackerLifecycle(t *testing.T) {
+	origCPU, origMem := watchCPU, watchMem
+	defer func() {
+		watchCPU = origCPU
+		watchMem = origMem
+	}()
+	watchCPU = 50
+	watchMem = 0
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sample := func(offset time.Duration, cpu float64) alertSample {
+		return alertSample{
+			time:  start.Add(offset),
+			procs: []process.Info{{PID: 42, Name: "worker", CPU: cpu}},
+		}
+	}
+
+	tracker := newAlertTracker(time.Minute)
+
+	events := tracker.update(sample(0, 90))
+	if len(events) != 1 || events[0].State != alertFiring {
+		t.Fatalf("first update = %+v, want one firing event", events)
+	}
+
+	// Still above the threshold but within the renotify interval: no event.
+	if events := tracker.update(sample(30*time.Second, 95)); len(events) != 0 {
+		t.Errorf("update within renotify interval = %+v, want none", events)
+	}
+
+	// Renotify interval elapsed: the alert fires again.
+	events = tracker.update(sample(61*time.Second, 95))
+	if len(events) != 1 || events[0].State != alertFiring || events[0].Value != 95 {
+		t.Errorf("update after renotify interval = %+v, want one firing event with value 95", events)
+	}
+
+	// Drops below the threshold: resolved with the current value.
+	events = tracker.update(sample(90*time.Second, 10))
+	if len(events) != 1 || events[0].State != alertResolved {
+		t.Fatalf("update below threshold = %+v, want one resolved event", events)
+	}
+	if events[0].Value != 10 {
+		t.Errorf("resolved Value = %f, want 10", events[0].Value)
+	}
+
+	// Nothing firing any more.
+	if events := tracker.update(sample(2*time.Minute, 10)); len(events) != 0 {
+		t.Errorf("update after resolve = %+v, want none", events)
+	}
+}
+
+func TestAlertTrackerNoRenotify(t *testing.T) {
+	origCPU := watchCPU
+	defer func() { watchCPU = origCPU }()
+	watchCPU = 50
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newAlertTracker(0)
+	procs := []process.Info{{PID: 7, Name: "spin", CPU: 99}}
+
+	if events := tracker.update(alertSample{time: start, procs: procs}); len(events) != 1 {
+		t.Fatalf("first update returned %d events, want 1", len(events))
+	}
+	if events := tracker.update(alertSample{time: start.Add(time.Hour), procs: procs}); len(events) != 0 {
+		t.Errorf("update with renotify disabled = %+v, want none", events)
+	}
+
+	// Process exits: resolved with a zero value.
+	events := tracker.update(alertSample{time: start.Add(2 * time.Hour)})
+	if len(events) != 1 || events[0].State != alertResolved || events[0].Value != 0 {
+		t.Errorf("update after exit = %+v, want one resolved event with value 0", events)
+	}
+}
+
+func TestParseFDThreshold(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantLimit   float64
+		wantPercent bool
+		wantErr     bool
+	}{
+		{"", 0, false, false},
+		{"80%", 80, true, false},
+		{"5000", 5000, false, false},
+		{"150%", 0, false, true},
+		{"-5", 0, false, true},
+		{"lots", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			limit, percent, err := parseFDThreshold(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFDThreshold(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if limit != tt.wantLimit || percent != tt.wantPercent {
+				t.Errorf("parseFDThreshold(%q) = %v, %v, want %v, %v", tt.input, limit, percent, tt.wantLimit, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestEvaluateThresholdsFDs(t *testing.T) {
+	origCPU, origMem, origLimit, origPercent := watchCPU, watchMem, fdLimit, fdPercent
+	defer func() {
+		watchCPU, watchMem, fdLimit, fdPercent = origCPU, origMem, origLimit, origPercent
+	}()
+	watchCPU, watchMem = 0, 0
+	fdLimit, fdPercent = 80, true
+
+	s := alertSample{
+		time: time.Now(),
+		procs: []process.Info{
+			{PID: 1, Name: "full"},
+			{PID: 2, Name: "fine"},
+			{PID: 3, Name: "unlimited"},
+		},
+		fds: map[int]process.FDUsage{
+			1: {PID: 1, OpenFiles: 900, Limit: 1000},
+			2: {PID: 2, OpenFiles: 100, Limit: 1000},
+			3: {PID: 3, OpenFiles: 100000},
+		},
+	}
+
+	alerts := evaluateThresholds(s)
+	if len(alerts) != 1 {
+		t.Fatalf("evaluateThresholds() returned %d alerts, want 1", len(alerts))
+	}
+	if alerts[0].Threshold != "fds" || alerts[0].Process.PID != 1 || alerts[0].Value != 90 {
+		t.Errorf("alert = %+v, want fds alert for PID 1 at 90%%", alerts[0])
+	}
+
+	fdPercent, fdLimit = false, 50000
+	alerts = evaluateThresholds(s)
+	if len(alerts) != 1 || alerts[0].Threshold != "fds_count" || alerts[0].Process.PID != 3 {
+		t.Errorf("count alerts = %+v, want one fds_count alert for PID 3", alerts)
+	}
+}
+
+func TestEvaluateThresholdsCrashLoop(t *testing.T) {
+	origCPU, origMem, origLimit, origLoop := watchCPU, watchMem, fdLimit, watchCrashLoop
+	defer func() {
+		watchCPU, watchMem, fdLimit, watchCrashLoop = origCPU, origMem, origLimit, origLoop
+	}()
+	watchCPU, watchMem, fdLimit = 0, 0, 0
+	watchCrashLoop = 3
+
+	now := time.Now()
+	procs := []process.Info{{PID: 10, Name: "worker"}, {PID: 11, Name: "stable"}}
+	s := alertSample{
+		time:  now,
+		procs: procs,
+		loops: map[int]process.CrashLoop{10: {Process: "worker", PID: 10, Crashes: 4}},
+	}
+
+	alerts := evaluateThresholds(s)
+	if len(alerts) != 1 {
+		t.Fatalf("evaluateThresholds() returned %d alerts, want 1", len(alerts))
+	}
+	if a := alerts[0]; a.Threshold != "crash_loop" || a.Process.PID != 10 || a.Value != 4 || a.Limit != 3 {
+		t.Errorf("alert = %+v, want crash_loop alert for PID 10 with 4 crashes", a)
+	}
+	if got := formatAlertValue("crash_loop", 4); got != "4" {
+		t.Errorf("formatAlertValue(crash_loop) = %q, want 4", got)
+	}
+
+	tracker := newAlertTracker(0)
+	tracker.update(s)
+	events := tracker.update(alertSample{time: now.Add(time.Minute), procs: procs, loops: map[int]process.CrashLoop{}})
+	if len(events) != 1 || events[0].State != alertResolved || events[0].Value != 0 {
+		t.Errorf("events = %+v, want crash_loop resolved once the process stops crashing", events)
+	}
+}
+
+func TestEventQueueDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	q := startEventQueue(func(Alert, time.Time) error {
+		<-release // a hook that hangs
+		return nil
+	})
+	defer close(release)
+
+	start := time.Now()
+	// More events than the queue holds: the overflow is dropped.
+	for range eventQueueSize + 5 {
+		q.add(testAlert(), time.Now())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("add() took %v while a hook hangs, want it not to wait", elapsed)
+	}
+	if q.stop(50 * time.Millisecond) {
+		t.Error("stop() = true, want false while an event is stuck")
+	}
+}
+
+func TestEventQueueOrder(t *testing.T) {
+	var handled []string
+	q := startEventQueue(func(alert Alert, at time.Time) error {
+		handled = append(handled, alert.State)
+		return nil
+	})
+	firing, resolved := testAlert(), testAlert()
+	resolved.State = alertResolved
+	q.add(firing, time.Now())
+	q.add(resolved, time.Now())
+	if !q.stop(5 * time.Second) {
+		t.Fatal("stop() = false, want the queued events handled")
+	}
+	if strings.Join(handled, ",") != "firing,resolved" {
+		t.Errorf("handled %v, want firing then resolved", handled)
+	}
+}
+
+func TestEventQueueError(t *testing.T) {
+	q := startEventQueue(func(Alert, time.Time) error {
+		return errors.New("stdout closed")
+	})
+	q.add(testAlert(), time.Now())
+	select {
+	case err := <-q.errs:
+		if err == nil || err.Error() != "stdout closed" {
+			t.Errorf("errs = %v, want the handler's error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+	q.stop(time.Second)
+}
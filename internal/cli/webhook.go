@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// webhookBackoff is the delay before the first webhook retry; it doubles on
+// each subsequent attempt.
+var webhookBackoff = 500 * time.Millisecond
+
+// webhookQueueSize is the number of alerts that can wait for delivery before
+// new ones are dropped.
+const webhookQueueSize = 64
+
+// webhookDrainTimeout bounds how long the watcher waits on exit for queued
+// alerts to be delivered.
+const webhookDrainTimeout = 10 * time.Second
+
+// webhookNotifier POSTs alerts to an HTTP endpoint.
+type webhookNotifier struct {
+	url      string
+	headers  http.Header
+	template *template.Template // nil sends the Alert as JSON
+	retries  int
+	backoff  time.Duration
+	client   *http.Client
+
+	queue chan Alert    // set by start
+	done  chan struct{} // closed when the queue is drained
+}
+
+// newWebhookNotifier builds a notifier for url. Headers use the "Key: Value"
+// form. tmpl is a text/template rendered with the Alert, or "@path" to read
+// the template from a file; empty sends the Alert JSON unchanged.
+func newWebhookNotifier(url string, headers []string, tmpl string, timeout time.Duration, retries int) (*webhookNotifier, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("invalid webhook URL %q: must start with http:// or https://", url)
+	}
+	if retries < 0 {
+		return nil, fmt.Errorf("webhook retries must not be negative")
+	}
+
+	n := &webhookNotifier{
+		url:     url,
+		headers: make(http.Header),
+		retries: retries,
+		backoff: webhookBackoff,
+		client:  &http.Client{Timeout: timeout},
+	}
+
+	for _, h := range headers {
+		k, v, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid webhook header %q (use \"Key: Value\")", h)
+		}
+		n.headers.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	if n.headers.Get("Content-Type") == "" {
+		n.headers.Set("Content-Type", "application/json")
+	}
+
+	if tmpl != "" {
+		if path, ok := strings.CutPrefix(tmpl, "@"); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read webhook template: %w", err)
+			}
+			tmpl = string(data)
+		}
+		t, err := template.New("webhook").Funcs(template.FuncMap{"json": toJSON}).Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template: %w", err)
+		}
+		n.template = t
+	}
+
+	return n, nil
+}
+
+// toJSON is the "json" template function.
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// payload renders the request body for alert.
+func (n *webhookNotifier) payload(alert Alert) ([]byte, error) {
+	if n.template == nil {
+		return json.Marshal(alert)
+	}
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, alert); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// start delivers queued alerts in the background until stop is called, so
+// that a slow or unreachable endpoint does not hold up the watcher.
+func (n *webhookNotifier) start() {
+	n.queue = make(chan Alert, webhookQueueSize)
+	n.done = make(chan struct{})
+	go func() {
+		defer close(n.done)
+		for alert := range n.queue {
+			if err := n.send(alert); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}()
+}
+
+// enqueue queues alert for delivery without waiting. If the queue is full
+// the alert is dropped with a warning.
+func (n *webhookNotifier) enqueue(alert Alert) {
+	select {
+	case n.queue <- alert:
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: webhook queue full, dropping %s alert for PID %d\n",
+			alert.Threshold, alert.Process.PID)
+	}
+}
+
+// stop waits up to timeout for the queued alerts to be delivered. It reports
+// whether they all were; any left are abandoned.
+func (n *webhookNotifier) stop(timeout time.Duration) bool {
+	close(n.queue)
+	select {
+	case <-n.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// send POSTs alert, retrying with exponential backoff on network errors,
+// 429, and 5xx responses.
+func (n *webhookNotifier) send(alert Alert) error {
+	body, err := n.payload(alert)
+	if err != nil {
+		return err
+	}
+
+	delay := n.backoff
+	var lastErr error
+	for attempt := 0; attempt <= n.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		retry, err := n.post(body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return fmt.Errorf("webhook delivery to %s failed: %w", n.url, lastErr)
+}
+
+// post makes a single delivery attempt. It reports whether a failure is
+// worth retrying.
+func (n *webhookNotifier) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header = n.headers.Clone()
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func testAlert() Alert {
+	return Alert{
+		Timestamp: "2026-01-01T00:00:00Z",
+		State:     alertFiring,
+		Threshold: "cpu",
+		Value:     97.5,
+		Limit:     80,
+		Process:   process.Info{PID: 321, Name: "builder"},
+	}
+}
+
+func TestWebhookSendJSON(t *testing.T) {
+	var got Alert
+	var gotHeader, gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		gotHeader = r.Header.Get("X-Token")
+		gotContentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n, err := newWebhookNotifier(srv.URL, []string{"X-Token: secret"}, "", time.Second, 0)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	if err := n.send(testAlert()); err != nil {
+		t.Fatalf("send() error: %v", err)
+	}
+
+	if got.Process.PID != 321 || got.Value != 97.5 {
+		t.Errorf("received alert = %+v, want PID 321 value 97.5", got)
+	}
+	if gotHeader != "secret" {
+		t.Errorf("X-Token = %q, want secret", gotHeader)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+}
+
+func TestWebhookTemplate(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	tmplPath := filepath.Join(t.TempDir(), "body.tmpl")
+	tmpl := `{"text": {{json (printf "%s %s %.0f" .Process.Name .Threshold .Value)}}}`
+	if err := os.WriteFile(tmplPath, []byte(tmpl), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	n, err := newWebhookNotifier(srv.URL, nil, "@"+tmplPath, time.Second, 0)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	if err := n.send(testAlert()); err != nil {
+		t.Fatalf("send() error: %v", err)
+	}
+
+	want := `{"text": "builder cpu 98"}`
+	if body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n, err := newWebhookNotifier(srv.URL, nil, "", time.Second, 3)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	n.backoff = time.Millisecond
+
+	if err := n.send(testAlert()); err != nil {
+		t.Fatalf("send() error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("server called %d times, want 3", calls.Load())
+	}
+}
+
+func TestWebhookNoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n, err := newWebhookNotifier(srv.URL, nil, "", time.Second, 3)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	n.backoff = time.Millisecond
+
+	if err := n.send(testAlert()); err == nil {
+		t.Error("send() should fail on 400")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server called %d times, want 1", calls.Load())
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	n, err := newWebhookNotifier(srv.URL, nil, "", 20*time.Millisecond, 1)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	n.backoff = time.Millisecond
+
+	if err := n.send(testAlert()); err == nil {
+		t.Error("send() should fail when the endpoint times out")
+	}
+}
+
+func TestNewWebhookNotifierInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		headers []string
+		tmpl    string
+	}{
+		{"bad scheme", "ftp://example.com", nil, ""},
+		{"bad header", "http://localhost", []string{"NoColon"}, ""},
+		{"bad template", "http://localhost", nil, "{{.Nope"},
+		{"missing template file", "http://localhost", nil, "@/nonexistent/template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newWebhookNotifier(tt.url, tt.headers, tt.tmpl, time.Second, 0); err == nil {
+				t.Error("newWebhookNotifier() should return an error")
+			}
+		})
+	}
+}
+
+func TestWebhookQueueDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	n, err := newWebhookNotifier(srv.URL, nil, "", 5*time.Second, 0)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	n.start()
+
+	start := time.Now()
+	// More alerts than the queue holds: the overflow is dropped.
+	for range webhookQueueSize + 5 {
+		n.enqueue(testAlert())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("enqueue() took %v against a hanging endpoint, want it not to wait", elapsed)
+	}
+
+	start = time.Now()
+	if n.stop(50 * time.Millisecond) {
+		t.Error("stop() = true, want false while deliveries are stuck")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("stop() took %v, want it to give up after its timeout", elapsed)
+	}
+}
+
+func TestWebhookStopDrainsQueue(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	n, err := newWebhookNotifier(srv.URL, nil, "", time.Second, 0)
+	if err != nil {
+		t.Fatalf("newWebhookNotifier() error: %v", err)
+	}
+	n.start()
+	for range 3 {
+		n.enqueue(testAlert())
+	}
+	if !n.stop(5 * time.Second) {
+		t.Fatal("stop() = false, want the queued alerts delivered")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("endpoint got %d requests, want 3", got)
+	}
+}
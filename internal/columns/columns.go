@@ -0,0 +1,313 @@
+// Package columns defines the process table columns that can be chosen for
+// the CLI tables and the TUI.
+package columns
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// source is the extra data a column needs beyond what process.List reads.
+type source int
+
+const (
+	fromList source = iota
+	fromPSDetails
+	fromThreads
+	fromPorts
+	fromOpenFiles
+)
+
+// Column is a process table column.
+type Column struct {
+	// ID names the column in --columns and the config file.
+	ID string
+	// Title is the table header.
+	Title string
+	// Width is the column width in the TUI. Longer values are truncated.
+	Width int
+	// Right aligns the column to the right, for numbers.
+	Right bool
+	// Desc sorts the column in descending order by default, so that the
+	// busiest or newest processes come first.
+	Desc bool
+
+	value  func(p process.Info, now time.Time) string
+	less   func(a, b process.Info) bool
+	source source
+}
+
+// Value formats the column for p. now is used by the elapsed column.
+func (c Column) Value(p process.Info, now time.Time) string {
+	return c.value(p, now)
+}
+
+var all = []Column{
+	{
+		ID: "pid", Title: "PID", Width: 8,
+		value: func(p process.Info, _ time.Time) string { return strconv.Itoa(p.PID) },
+		less:  func(a, b process.Info) bool { return a.PID < b.PID },
+	},
+	{
+		ID: "ppid", Title: "PPID", Width: 8,
+		value: func(p process.Info, _ time.Time) string { return strconv.Itoa(p.PPID) },
+		less:  func(a, b process.Info) bool { return a.PPID < b.PPID },
+	},
+	{
+		ID: "name", Title: "NAME", Width: 20,
+		value: func(p process.Info, _ time.Time) string { return p.Name },
+		less:  func(a, b process.Info) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	},
+	{
+		ID: "user", Title: "USER", Width: 10,
+		value: func(p process.Info, _ time.Time) string { return p.User },
+		less:  func(a, b process.Info) bool { return a.User < b.User },
+	},
+	{
+		ID: "cpu", Title: "CPU%", Width: 8, Right: true, Desc: true,
+		value: func(p process.Info, _ time.Time) string { return fmt.Sprintf("%.1f", p.CPU) },
+		less:  func(a, b process.Info) bool { return a.CPU < b.CPU },
+	},
+	{
+		ID: "mem", Title: "MEM%", Width: 8, Right: true, Desc: true,
+		value: func(p process.Info, _ time.Time) string { return fmt.Sprintf("%.1f", p.Mem) },
+		less:  func(a, b process.Info) bool { return a.Mem < b.Mem },
+	},
+	{
+		ID: "rss", Title: "RSS", Width: 8, Right: true, Desc: true,
+		value: func(p process.Info, _ time.Time) string { return formatKB(p.RSS) },
+		less:  func(a, b process.Info) bool { return a.RSS < b.RSS },
+	},
+	{
+		ID: "vsz", Title: "VSZ", Width: 8, Right: true, Desc: true, source: fromPSDetails,
+		value: func(p process.Info, _ time.Time) string { return formatKB(p.VSZ) },
+		less:  func(a, b process.Info) bool { return a.VSZ < b.VSZ },
+	},
+	{
+		ID: "threads", Title: "THR", Width: 5, Right: true, Desc: true, source: fromThreads,
+		value: func(p process.Info, _ time.Time) string { return formatCount(p.Threads) },
+		less:  func(a, b process.Info) bool { return a.Threads < b.Threads },
+	},
+	{
+		ID: "start", Title: "START", Width: 8, Desc: true, source: fromPSDetails,
+		value: func(p process.Info, now time.Time) string { return formatStart(p.Started, now) },
+		less:  func(a, b process.Info) bool { return a.Started.Before(b.Started) },
+	},
+	{
+		ID: "elapsed", Title: "ELAPSED", Width: 11, Right: true, source: fromPSDetails,
+		value: func(p process.Info, now time.Time) string { return formatElapsed(p.Started, now) },
+		// The most recently started process has the shortest elapsed time.
+		less: func(a, b process.Info) bool { return a.Started.After(b.Started) },
+	},
+	{
+		ID: "tty", Title: "TTY", Width: 8, source: fromPSDetails,
+		value: func(p process.Info, _ time.Time) string { return orDash(p.TTY) },
+		less:  func(a, b process.Info) bool { return a.TTY < b.TTY },
+	},
+	{
+		ID: "nice", Title: "NI", Width: 4, Right: true, source: fromPSDetails,
+		value: func(p process.Info, _ time.Time) string { return strconv.Itoa(p.Nice) },
+		less:  func(a, b process.Info) bool { return a.Nice < b.Nice },
+	},
+	{
+		ID: "ports", Title: "PORTS", Width: 14, Desc: true, source: fromPorts,
+		value: func(p process.Info, _ time.Time) string { return formatPorts(p.Ports) },
+		less:  func(a, b process.Info) bool { return len(a.Ports) < len(b.Ports) },
+	},
+	{
+		ID: "files", Title: "FILES", Width: 6, Right: true, Desc: true, source: fromOpenFiles,
+		value: func(p process.Info, _ time.Time) string { return formatCount(p.OpenFiles) },
+		less:  func(a, b process.Info) bool { return a.OpenFiles < b.OpenFiles },
+	},
+	{
+		ID: "state", Title: "STATE", Width: 6,
+		value: func(p process.Info, _ time.Time) string { return p.State },
+		less:  func(a, b process.Info) bool { return a.State < b.State },
+	},
+	{
+		ID: "command", Title: "COMMAND", Width: 40,
+		value: func(p process.Info, _ time.Time) string { return p.Command },
+		less:  func(a, b process.Info) bool { return a.Command < b.Command },
+	},
+}
+
+// defaultIDs are the columns shown when none are configured.
+var defaultIDs = []string{"pid", "name", "user", "cpu", "mem", "state", "command"}
+
+// All returns every column, in the order they are offered.
+func All() []Column {
+	return append([]Column(nil), all...)
+}
+
+// Default returns the columns shown when none are configured.
+func Default() []Column {
+	cols, _ := Lookup(defaultIDs)
+	return cols
+}
+
+// ByID returns the column with the given ID, ignoring case.
+func ByID(id string) (Column, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, c := range all {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// Parse parses a comma-separated list of column IDs, e.g. "pid,name,rss".
+func Parse(spec string) ([]Column, error) {
+	return Lookup(strings.Split(spec, ","))
+}
+
+// Lookup returns the columns with the given IDs, in order. Empty IDs are
+// skipped, and at least one column is required.
+func Lookup(ids []string) ([]Column, error) {
+	var cols []Column
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+		c, ok := ByID(id)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", strings.TrimSpace(id), strings.Join(IDs(all), ", "))
+		}
+		if seen[c.ID] {
+			return nil, fmt.Errorf("column %q is listed more than once", c.ID)
+		}
+		seen[c.ID] = true
+		cols = append(cols, c)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return cols, nil
+}
+
+// IDs returns the IDs of cols.
+func IDs(cols []Column) []string {
+	ids := make([]string, len(cols))
+	for i, c := range cols {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// Sort sorts procs by col, in descending order if desc is set. Ties are
+// broken by PID so that rows with equal values keep their places across
+// refreshes.
+func Sort(procs []process.Info, col Column, desc bool) {
+	sort.SliceStable(procs, func(i, j int) bool {
+		a, b := procs[i], procs[j]
+		if col.less(a, b) {
+			return !desc
+		}
+		if col.less(b, a) {
+			return desc
+		}
+		return a.PID < b.PID
+	})
+}
+
+// Fill reads the extra data cols need into procs, running each lookup once.
+// It is best effort: columns whose data cannot be read are left blank.
+func Fill(procs []process.Info, cols []Column) {
+	done := make(map[source]bool)
+	for _, c := range cols {
+		if c.source == fromList || done[c.source] {
+			continue
+		}
+		done[c.source] = true
+		switch c.source {
+		case fromPSDetails:
+			process.AddPSDetails(procs)
+		case fromThreads:
+			process.AddThreadCounts(procs)
+		case fromPorts:
+			process.AddListeningPorts(procs)
+		case fromOpenFiles:
+			process.AddOpenFileCounts(procs)
+		}
+	}
+}
+
+// formatKB formats a size in KB with a K, M, or G suffix.
+func formatKB(kb int64) string {
+	switch {
+	case kb >= 1024*1024:
+		return fmt.Sprintf("%.1fG", float64(kb)/(1024*1024))
+	case kb >= 1024:
+		return fmt.Sprintf("%.1fM", float64(kb)/1024)
+	default:
+		return fmt.Sprintf("%dK", kb)
+	}
+}
+
+// formatCount formats a count that is 0 when it could not be read as "-".
+func formatCount(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.Itoa(n)
+}
+
+// orDash returns s, or "-" if it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// formatStart formats a start time like ps: the time of day for processes
+// started in the last 24 hours, else the date.
+func formatStart(t, now time.Time) string {
+	switch {
+	case t.IsZero():
+		return "-"
+	case now.Sub(t) < 24*time.Hour:
+		return t.Format("15:04:05")
+	case t.Year() == now.Year():
+		return t.Format("Jan02")
+	default:
+		return t.Format("2006")
+	}
+}
+
+// formatElapsed formats the time since t like the etime column of ps,
+// "[[dd-]hh:]mm:ss".
+func formatElapsed(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	secs := int(max(now.Sub(t), 0) / time.Second)
+	days, hours, mins := secs/86400, secs/3600%24, secs/60%60
+	secs %= 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, hours, mins, secs)
+	case hours > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", hours, mins, secs)
+	default:
+		return fmt.Sprintf("%02d:%02d", mins, secs)
+	}
+}
+
+// formatPorts formats listening ports as a comma-separated list.
+func formatPorts(ports []int) string {
+	if len(ports) == 0 {
+		return "-"
+	}
+	s := make([]string, len(ports))
+	for i, p := range ports {
+		s[i] = strconv.Itoa(p)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,108 @@
+package columns
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestParse(t *testing.T) {
+	cols, err := Parse("PID, name,rss,,elapsed")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if got := strings.Join(IDs(cols), ","); got != "pid,name,rss,elapsed" {
+		t.Errorf("Parse() = %s, want pid,name,rss,elapsed", got)
+	}
+
+	for _, spec := range []string{"pid,bogus", "pid,PID", "", " , "} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should return error", spec)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if got := strings.Join(IDs(Default()), ","); got != "pid,name,user,cpu,mem,state,command" {
+		t.Errorf("Default() = %s", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	procs := []process.Info{
+		{PID: 3, Name: "b", CPU: 1},
+		{PID: 1, Name: "C", CPU: 5},
+		{PID: 2, Name: "a", CPU: 1},
+	}
+	pids := func() []int {
+		var r []int
+		for _, p := range procs {
+			r = append(r, p.PID)
+		}
+		return r
+	}
+
+	cpu, _ := ByID("cpu")
+	Sort(procs, cpu, true)
+	if got := pids(); got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("cpu descending = %v, want [1 2 3] (ties by PID)", got)
+	}
+
+	name, _ := ByID("name")
+	Sort(procs, name, false)
+	if got := pids(); got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("name ascending = %v, want [2 3 1]", got)
+	}
+	Sort(procs, name, true)
+	if got := pids(); got[0] != 1 || got[1] != 3 || got[2] != 2 {
+		t.Errorf("name descending = %v, want [1 3 2]", got)
+	}
+}
+
+func TestValue(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	p := process.Info{
+		RSS:     2048,
+		VSZ:     3 * 1024 * 1024,
+		Started: now.Add(-(26*time.Hour + 3*time.Minute + 4*time.Second)),
+		Ports:   []int{80, 443},
+	}
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"rss", "2.0M"},
+		{"vsz", "3.0G"},
+		{"elapsed", "1-02:03:04"},
+		{"start", "Oct17"},
+		{"ports", "80,443"},
+		{"threads", "-"},
+	}
+	for _, tt := range tests {
+		c, _ := ByID(tt.id)
+		if got := c.Value(p, now); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{7 * time.Second, "00:07"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "01:02:03"},
+	}
+	for _, tt := range tests {
+		if got := formatElapsed(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := formatElapsed(time.Time{}, now); got != "-" {
+		t.Errorf("formatElapsed(zero) = %q, want -", got)
+	}
+}
@@ -0,0 +1,95 @@
+// Package config loads the optional pstop configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config is the contents of the pstop configuration file.
+type Config struct {
+	// Columns lists the process table columns shown by list, find, top,
+	// and the TUI, e.g. ["pid", "name", "rss", "command"].
+	Columns []string `json:"columns,omitempty"`
+	Crashes Crashes  `json:"crashes"`
+}
+
+// Crashes configures the crashes command.
+type Crashes struct {
+	// Dirs lists report directories to scan instead of the built-in ones.
+	Dirs []string `json:"dirs,omitempty"`
+	// Logs lists glob patterns of log files to search for Go panics, Python
+	// tracebacks, and JVM fatal error logs.
+	Logs []string `json:"logs,omitempty"`
+	// Redact lists extra regular expressions whose matches are removed from
+	// exported reports.
+	Redact []string `json:"redact,omitempty"`
+}
+
+// Path returns the location of the configuration file: $PSTOP_CONFIG if set,
+// otherwise pstop/config.json under $XDG_CONFIG_HOME or ~/.config.
+func Path() string {
+	if p := os.Getenv("PSTOP_CONFIG"); p != "" {
+		return p
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "pstop", "config.json")
+}
+
+// Load reads the configuration file at Path. A missing file is not an error
+// and yields an empty Config.
+func Load() (*Config, error) {
+	path := Path()
+	if path == "" {
+		return &Config{}, nil
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the configuration file at path. A missing file is not an
+// error and yields an empty Config.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	for i, dir := range cfg.Crashes.Dirs {
+		cfg.Crashes.Dirs[i] = ExpandHome(dir)
+	}
+	for i, pattern := range cfg.Crashes.Logs {
+		cfg.Crashes.Logs[i] = ExpandHome(pattern)
+	}
+	return &cfg, nil
+}
+
+// ExpandHome replaces a leading "~" in path with the user's home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"crashes": {"dirs": ["~/reports", "/var/crash"], "logs": ["~/logs/*.log"]}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	want := []string{filepath.Join(home, "reports"), "/var/crash"}
+	if len(cfg.Crashes.Dirs) != len(want) {
+		t.Fatalf("Dirs = %v, want %v", cfg.Crashes.Dirs, want)
+	}
+	for i := range want {
+		if cfg.Crashes.Dirs[i] != want[i] {
+			t.Errorf("Dirs[%d] = %q, want %q", i, cfg.Crashes.Dirs[i], want[i])
+		}
+	}
+	if len(cfg.Crashes.Logs) != 1 || cfg.Crashes.Logs[0] != filepath.Join(home, "logs", "*.log") {
+		t.Errorf("Logs = %v, want expanded glob", cfg.Crashes.Logs)
+	}
+}
+
+func TestLoadFileColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"columns": ["pid", "name", "rss"]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if len(cfg.Columns) != 3 || cfg.Columns[2] != "rss" {
+		t.Errorf("Columns = %v, want [pid name rss]", cfg.Columns)
+	}
+}
+
+func TestLoadFileMissing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatalf("LoadFile(missing) error: %v", err)
+	}
+	if len(cfg.Crashes.Dirs) != 0 {
+		t.Errorf("Dirs = %v, want empty", cfg.Crashes.Dirs)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile(invalid) should return error")
+	}
+}
+
+func TestPathEnvOverride(t *testing.T) {
+	t.Setenv("PSTOP_CONFIG", "/tmp/custom.json")
+	if got := Path(); got != "/tmp/custom.json" {
+		t.Errorf("Path() = %q, want /tmp/custom.json", got)
+	}
+
+	t.Setenv("PSTOP_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got := Path(); got != "/xdg/pstop/config.json" {
+		t.Errorf("Path() = %q, want /xdg/pstop/config.json", got)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := map[string]string{
+		"~":          home,
+		"~/x/y":      filepath.Join(home, "x", "y"),
+		"/abs/path":  "/abs/path",
+		"~other/dir": "~other/dir",
+	}
+	for in, want := range tests {
+		if got := ExpandHome(in); got != want {
+			t.Errorf("ExpandHome(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
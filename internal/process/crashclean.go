@@ -0,0 +1,65 @@
+package process
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// CrashFile is a crash report file on disk.
+type CrashFile struct {
+	CrashReport
+	Size int64 `json:"size"` // bytes
+}
+
+// CrashUsage is the disk space used by the reports of one process.
+type CrashUsage struct {
+	Process string `json:"process"`
+	Count   int    `json:"count"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// SelectCrashFiles returns the report files in dirs written before the given
+// time, optionally limited to one process. Reports whose timestamp cannot be
+// parsed are never selected, since their age is unknown, and neither are
+// reports that no longer exist on disk.
+func SelectCrashFiles(dirs []string, before time.Time, proc string) []CrashFile {
+	var files []CrashFile
+	for _, r := range QueryCrashReports(CrashQuery{Dirs: dirs, Until: before, Process: proc}) {
+		if r.Path == "" || crashTime(r).IsZero() {
+			continue
+		}
+		fi, err := os.Stat(r.Path)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		files = append(files, CrashFile{CrashReport: r, Size: fi.Size()})
+	}
+	return files
+}
+
+// SummarizeCrashFiles totals files per process, largest first.
+func SummarizeCrashFiles(files []CrashFile) []CrashUsage {
+	byName := make(map[string]*CrashUsage)
+	for _, f := range files {
+		u, ok := byName[f.Process]
+		if !ok {
+			u = &CrashUsage{Process: f.Process}
+			byName[f.Process] = u
+		}
+		u.Count++
+		u.Bytes += f.Size
+	}
+
+	usage := make([]CrashUsage, 0, len(byName))
+	for _, u := range byName {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Bytes != usage[j].Bytes {
+			return usage[i].Bytes > usage[j].Bytes
+		}
+		return usage[i].Process < usage[j].Process
+	})
+	return usage
+}
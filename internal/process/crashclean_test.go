@@ -0,0 +1,63 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSelectCrashFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	old := writeIPS(t, dir, "old.ips", "1.0", now.AddDate(0, 0, -40), "main")
+	writeIPS(t, dir, "new.ips", "1.0", now.AddDate(0, 0, -1), "main")
+	hang := "Process:  HangApp [7]\nDate/Time:  " + now.AddDate(0, 0, -60).Format("2006-01-02 15:04:05 -0700") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "old.hang"), []byte(hang), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// No parseable timestamp: its age is unknown, so it must be kept.
+	if err := os.WriteFile(filepath.Join(dir, "unknown.hang"), []byte("Process:  Mystery [8]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	before := now.AddDate(0, 0, -30)
+	files := SelectCrashFiles([]string{dir}, before, "")
+	if len(files) != 2 {
+		t.Fatalf("SelectCrashFiles() returned %d files, want 2: %+v", len(files), files)
+	}
+	for _, f := range files {
+		fi, err := os.Stat(f.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Size != fi.Size() {
+			t.Errorf("Size of %s = %d, want %d", f.Path, f.Size, fi.Size())
+		}
+	}
+
+	files = SelectCrashFiles([]string{dir}, before, "myapp")
+	if len(files) != 1 || files[0].Path != old {
+		t.Errorf("SelectCrashFiles(myapp) = %+v, want only %s", files, old)
+	}
+}
+
+func TestSummarizeCrashFiles(t *testing.T) {
+	files := []CrashFile{
+		{CrashReport: CrashReport{Process: "small"}, Size: 10},
+		{CrashReport: CrashReport{Process: "big"}, Size: 500},
+		{CrashReport: CrashReport{Process: "big"}, Size: 700},
+		{CrashReport: CrashReport{Process: "small"}, Size: 20},
+	}
+	got := SummarizeCrashFiles(files)
+	want := []CrashUsage{{"big", 2, 1200}, {"small", 2, 30}}
+	if len(got) != len(want) {
+		t.Fatalf("SummarizeCrashFiles() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("usage[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
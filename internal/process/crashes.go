@@ -13,48 +13,161 @@ import (
 	"time"
 )
 
-// CrashReport holds summary information about a crash, hang, spin, or panic report.
+// CrashReport holds summary information about a crash, hang, spin, or panic
+// report, a Linux Apport or systemd-coredump crash, or a crash written by a
+// language runtime (a JVM fatal error log, or a Go panic or Python traceback
+// in a log file).
 type CrashReport struct {
-	Timestamp  string `json:"timestamp"`
-	Process    string `json:"process"`
-	PID        int    `json:"pid"`
-	ExceptType string `json:"exception_type"`
-	Signal     string `json:"signal"`
-	Path       string `json:"path"`
-	ReportType string `json:"report_type"` // crash, hang, spin, panic
+	Timestamp    string `json:"timestamp"`               // RFC3339 when the source timestamp could be parsed
+	RawTimestamp string `json:"raw_timestamp,omitempty"` // timestamp as written in the report
+	Process      string `json:"process"`
+	PID          int    `json:"pid"`
+	ExceptType   string `json:"exception_type"`
+	Signal       string `json:"signal"`
+	Path         string `json:"path"`
+	Line         int    `json:"line,omitempty"` // line a crash starts on, for crashes found in log files
+	ReportType   string `json:"report_type"`    // crash, hang, spin, panic, apport, coredump, jvm, go, python
 }
 
 // CrashDetail holds extended information about a specific crash report.
 type CrashDetail struct {
 	CrashReport
 	Version     string   `json:"version,omitempty"`
+	Message     string   `json:"message,omitempty"` // panic or exception message of runtime crashes
 	OSVersion   string   `json:"os_version,omitempty"`
 	CrashThread int      `json:"crash_thread,omitempty"`
 	Backtrace   []string `json:"backtrace,omitempty"`
+
+	// Threads and Images are only available for .ips crash reports.
+	Threads []CrashThread `json:"threads,omitempty"`
+	Images  []BinaryImage `json:"images,omitempty"`
+
+	// Hang holds the sampled call trees of .hang and .spin reports.
+	Hang *HangInfo `json:"hang,omitempty"`
+}
+
+// CrashThread is one thread of a crash report.
+type CrashThread struct {
+	Index     int          `json:"index"`
+	Name      string       `json:"name,omitempty"`
+	Queue     string       `json:"queue,omitempty"`
+	Triggered bool         `json:"triggered,omitempty"`
+	Frames    []CrashFrame `json:"frames"`
+}
+
+// CrashFrame is one stack frame of a crash report thread.
+type CrashFrame struct {
+	Image        string `json:"image,omitempty"`
+	ImageOffset  uint64 `json:"image_offset"`
+	Address      uint64 `json:"address,omitempty"` // 0 if the image is unknown
+	Symbol       string `json:"symbol,omitempty"`
+	SymbolOffset int    `json:"symbol_offset,omitempty"`
+}
+
+// String renders the frame as "symbol+offset" when symbolicated, otherwise
+// as "image + 0xoffset", falling back to the bare offset when the image is
+// unknown.
+func (f CrashFrame) String() string {
+	switch {
+	case f.Symbol != "":
+		return fmt.Sprintf("%s+%d", f.Symbol, f.SymbolOffset)
+	case f.Image != "":
+		return fmt.Sprintf("%s + 0x%x", f.Image, f.ImageOffset)
+	default:
+		return fmt.Sprintf("0x%x", f.ImageOffset)
+	}
+}
+
+// BinaryImage is a binary loaded into the crashed process.
+type BinaryImage struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Path  string `json:"path,omitempty"`
+	UUID  string `json:"uuid,omitempty"`
+	Base  uint64 `json:"base"` // load address
+	Size  uint64 `json:"size,omitempty"`
 }
 
-// diagnosticDirs returns the directories to scan for diagnostic reports.
-func diagnosticDirs() []string {
+// DefaultCrashDirs returns the built-in directories to scan for reports:
+// the macOS DiagnosticReports folders, the Apport crash directory, and the
+// systemd-coredump store.
+func DefaultCrashDirs() []string {
+	dirs := []string{"/Library/Logs/DiagnosticReports", "/var/crash", "/var/lib/systemd/coredump"}
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return []string{"/Library/Logs/DiagnosticReports"}
+		return dirs
 	}
-	return []string{
-		filepath.Join(home, "Library", "Logs", "DiagnosticReports"),
-		"/Library/Logs/DiagnosticReports",
+	return append([]string{filepath.Join(home, "Library", "Logs", "DiagnosticReports")}, dirs...)
+}
+
+// crashReportType returns the report type for a file name, or "" if the
+// file is not a recognised report.
+func crashReportType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ips":
+		return "crash"
+	case ".hang":
+		return "hang"
+	case ".spin":
+		return "spin"
+	case ".panic":
+		return "panic"
+	case ".crash":
+		return "apport"
+	}
+	if isJVMCrashName(name) {
+		return "jvm"
+	}
+	if _, ok := parseCoredumpName(name); ok {
+		return "coredump"
+	}
+	return ""
+}
+
+// CrashQuery selects crash reports.
+type CrashQuery struct {
+	Dirs    []string
+	Since   time.Time // zero for no lower bound
+	Until   time.Time // zero for no upper bound
+	Process string    // case-insensitive exact match, empty for all
+	Logs    []string  // glob patterns of log files to search for runtime crashes
+}
+
+// matches reports whether r passes the query's time and process filters.
+// Reports whose timestamp cannot be parsed are not filtered by time.
+func (q CrashQuery) matches(r CrashReport) bool {
+	if ts, err := parseCrashTimestamp(r.Timestamp); err == nil {
+		if !q.Since.IsZero() && ts.Before(q.Since) {
+			return false
+		}
+		if !q.Until.IsZero() && ts.After(q.Until) {
+			return false
+		}
 	}
+	return q.Process == "" || strings.EqualFold(r.Process, q.Process)
 }
 
-// ListCrashReports scans DiagnosticReports directories and returns recent reports.
+// ListCrashReports scans the default report directories and returns recent reports.
 func ListCrashReports(lastDuration string, processFilter string) ([]CrashReport, error) {
+	return ListCrashReportsIn(DefaultCrashDirs(), lastDuration, processFilter)
+}
+
+// ListCrashReportsIn scans dirs and returns recent reports.
+func ListCrashReportsIn(dirs []string, lastDuration string, processFilter string) ([]CrashReport, error) {
 	cutoff, err := parseDuration(lastDuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse duration %q: %w", lastDuration, err)
 	}
+	return QueryCrashReports(CrashQuery{Dirs: dirs, Since: cutoff, Process: processFilter}), nil
+}
 
+// QueryCrashReports scans the query's directories and returns the matching
+// reports, newest first. Reports whose timestamp cannot be parsed are not
+// filtered by time and sort last.
+func QueryCrashReports(q CrashQuery) []CrashReport {
 	var reports []CrashReport
 
-	for _, dir := range diagnosticDirs() {
+	for _, dir := range q.Dirs {
 		entries, err := os.ReadDir(dir)
 		if err != nil {
 			// Directory may not exist or be inaccessible; skip it.
@@ -67,18 +180,8 @@ func ListCrashReports(lastDuration string, processFilter string) ([]CrashReport,
 			}
 
 			name := entry.Name()
-			ext := strings.ToLower(filepath.Ext(name))
-			var reportType string
-			switch ext {
-			case ".ips":
-				reportType = "crash"
-			case ".hang":
-				reportType = "hang"
-			case ".spin":
-				reportType = "spin"
-			case ".panic":
-				reportType = "panic"
-			default:
+			reportType := crashReportType(name)
+			if reportType == "" {
 				continue
 			}
 
@@ -88,53 +191,118 @@ func ListCrashReports(lastDuration string, processFilter string) ([]CrashReport,
 				continue
 			}
 
-			// Apply time filter.
-			ts, err := parseCrashTimestamp(report.Timestamp)
-			if err == nil && ts.Before(cutoff) {
+			if !q.matches(report) {
 				continue
 			}
 
-			// Apply process filter.
-			if processFilter != "" && !strings.EqualFold(report.Process, processFilter) {
-				continue
-			}
+			reports = append(reports, report)
+		}
+	}
 
+	for _, report := range scanCrashLogs(q.Logs) {
+		if q.matches(report) {
 			reports = append(reports, report)
 		}
 	}
 
-	// Sort by timestamp descending (most recent first).
-	sort.Slice(reports, func(i, j int) bool {
-		return reports[i].Timestamp > reports[j].Timestamp
+	addCoredumpSignals(reports)
+	sortCrashReports(reports)
+	return reports
+}
+
+// crashTime returns the parsed timestamp of r, or the zero time if it cannot
+// be parsed.
+func crashTime(r CrashReport) time.Time {
+	t, _ := parseCrashTimestamp(r.Timestamp)
+	return t
+}
+
+// sortCrashReports orders reports newest first, regardless of the timestamp
+// format of their source.
+func sortCrashReports(reports []CrashReport) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		return crashTime(reports[i]).After(crashTime(reports[j]))
 	})
+}
+
+// normalizeTimestamp converts r.Timestamp to RFC3339, keeping the original
+// value in RawTimestamp. Unparseable timestamps are left unchanged.
+func normalizeTimestamp(r *CrashReport) {
+	if r.Timestamp == "" {
+		return
+	}
+	if r.RawTimestamp == "" {
+		r.RawTimestamp = r.Timestamp
+	}
+	if t, err := parseCrashTimestamp(r.Timestamp); err == nil {
+		r.Timestamp = t.Format(time.RFC3339)
+	}
+}
 
-	return reports, nil
+// addCoredumpSignals fills in signals for systemd-coredump reports from the
+// journal metadata, which is only queried if such reports are present.
+func addCoredumpSignals(reports []CrashReport) {
+	var meta coredumpIndex
+	for i := range reports {
+		if reports[i].ReportType != "coredump" || reports[i].Signal != "" {
+			continue
+		}
+		if meta == nil {
+			if meta = coredumpMetadata(); meta == nil {
+				return
+			}
+		}
+		if e, ok := meta.match(reports[i]); ok && e.Sig > 0 {
+			reports[i].Signal = linuxSignalName(e.Sig)
+		}
+	}
 }
 
-// GetCrashDetail reads and parses a specific crash report file.
+// GetCrashDetail reads and parses a specific crash report file. Other files
+// are searched for Go panics and Python tracebacks; path may end in
+// ":<line>" to select the crash starting on that line, otherwise the last
+// crash in the file is returned.
 func GetCrashDetail(path string) (*CrashDetail, error) {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".ips":
-		return parseIPSDetail(path)
-	case ".hang":
-		return parseHangDetail(path)
-	case ".spin":
-		return parseSpinDetail(path)
-	case ".panic":
-		return parsePanicDetail(path)
+	var detail *CrashDetail
+	var err error
+	switch crashReportType(filepath.Base(path)) {
+	case "crash":
+		detail, err = parseIPSDetail(path)
+	case "hang":
+		detail, err = parseHangDetail(path)
+	case "spin":
+		detail, err = parseSpinDetail(path)
+	case "panic":
+		detail, err = parsePanicDetail(path)
+	case "apport":
+		detail, err = parseApportDetail(path)
+	case "coredump":
+		detail, err = parseCoredumpDetail(path)
+	case "jvm":
+		detail, err = parseJVMDetail(path)
 	default:
-		return nil, fmt.Errorf("unsupported report format: %s", ext)
+		file, line := parseLogLocation(path)
+		detail, err = crashLogDetail(file, line)
 	}
+	if err != nil {
+		return nil, err
+	}
+	normalizeTimestamp(&detail.CrashReport)
+	return detail, nil
 }
 
-// parseDuration parses a human-readable duration string like "24h", "7d", "30d".
-// Returns the cutoff time (now - duration).
+// parseDuration parses a human-readable duration string like "30m", "24h",
+// "7d", or "2w". Returns the cutoff time (now - duration).
 func parseDuration(s string) (time.Time, error) {
 	if s == "" {
 		s = "7d"
 	}
+	return relativeTime(s, time.Now())
+}
 
+// relativeTime returns now minus a duration such as "30m", "24h", "7d", or
+// "2w".
+func relativeTime(s string, now time.Time) (time.Time, error) {
 	s = strings.TrimSpace(strings.ToLower(s))
 	if len(s) < 2 {
 		return time.Time{}, fmt.Errorf("invalid duration: %q", s)
@@ -150,15 +318,49 @@ func parseDuration(s string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("duration must be positive: %q", s)
 	}
 
-	now := time.Now()
 	switch unit {
+	case 'm':
+		return now.Add(-time.Duration(num) * time.Minute), nil
 	case 'h':
 		return now.Add(-time.Duration(num) * time.Hour), nil
 	case 'd':
 		return now.AddDate(0, 0, -num), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*num), nil
 	default:
-		return time.Time{}, fmt.Errorf("unknown duration unit %q (use h or d)", string(unit))
+		return time.Time{}, fmt.Errorf("unknown duration unit %q (use m, h, d, or w)", string(unit))
+	}
+}
+
+// ParseTimeBound parses a --since or --until value: an RFC3339 timestamp, a
+// local date or date and time ("2026-02-10", "2026-02-10 14:30"), "now", or a
+// relative value such as "30m", "24h", "7d", or "2w" (optionally followed by
+// "ago"), which is subtracted from now.
+func ParseTimeBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "now") {
+		return now, nil
+	}
+
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	rel := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(s), "ago"))
+	if t, err := relativeTime(rel, now); err == nil {
+		return t, nil
 	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use RFC3339, YYYY-MM-DD, or a relative value like 30m, 24h, 7d, 2w)", s)
 }
 
 // parseCrashTimestamp attempts to parse a timestamp from a crash report.
@@ -166,13 +368,17 @@ func parseCrashTimestamp(ts string) (time.Time, error) {
 	// .ips files use: "2026-02-08 22:58:16.00 -0500"
 	formats := []string{
 		"2006-01-02 15:04:05.00 -0700",
+		"2006-01-02 15:04:05.000 -0700",
 		"2006-01-02 15:04:05.0000 -0700",
 		"2006-01-02 15:04:05 -0700",
 		"2006-01-02 15:04:05",
 		time.RFC3339,
+		time.ANSIC, // Apport: "Tue Feb 10 14:30:00 2026"
 	}
+	// Timestamps without a zone are in the local time of the machine that
+	// wrote them.
 	for _, fmt := range formats {
-		if t, err := time.Parse(fmt, ts); err == nil {
+		if t, err := time.ParseInLocation(fmt, ts, time.Local); err == nil {
 			return t, nil
 		}
 	}
@@ -181,11 +387,12 @@ func parseCrashTimestamp(ts string) (time.Time, error) {
 
 // ipsHeader represents the first-line JSON header of an .ips file.
 type ipsHeader struct {
-	AppName   string `json:"app_name"`
-	Name      string `json:"name"`
-	Timestamp string `json:"timestamp"`
-	BugType   string `json:"bug_type"`
-	OSVersion string `json:"os_version"`
+	AppName    string `json:"app_name"`
+	AppVersion string `json:"app_version"`
+	Name       string `json:"name"`
+	Timestamp  string `json:"timestamp"`
+	BugType    string `json:"bug_type"`
+	OSVersion  string `json:"os_version"`
 }
 
 // ipsBody represents key fields in the JSON body of an .ips file.
@@ -200,11 +407,14 @@ type ipsBody struct {
 		Train string `json:"train"`
 		Build string `json:"build"`
 	} `json:"osVersion"`
-	Threads []ipsThread `json:"threads"`
+	Threads    []ipsThread `json:"threads"`
+	UsedImages []ipsImage  `json:"usedImages"`
 }
 
 // ipsThread represents a thread in the .ips crash report.
 type ipsThread struct {
+	Name      string     `json:"name"`
+	Queue     string     `json:"queue"`
 	Triggered bool       `json:"triggered"`
 	Frames    []ipsFrame `json:"frames"`
 }
@@ -213,24 +423,46 @@ type ipsThread struct {
 type ipsFrame struct {
 	Symbol         string `json:"symbol"`
 	SymbolLocation int    `json:"symbolLocation"`
-	ImageOffset    int    `json:"imageOffset"`
-	ImageIndex     int    `json:"imageIndex"`
+	ImageOffset    uint64 `json:"imageOffset"`
+	ImageIndex     *int   `json:"imageIndex"`
+}
+
+// ipsImage represents an entry of the usedImages section of an .ips file.
+type ipsImage struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	UUID string `json:"uuid"`
+	Base uint64 `json:"base"`
+	Size uint64 `json:"size"`
 }
 
 // parseCrashSummary reads the first line(s) of a report file and extracts summary info.
 func parseCrashSummary(path string, reportType string) (CrashReport, error) {
+	var report CrashReport
+	var err error
 	switch reportType {
 	case "crash":
-		return parseIPSSummary(path)
+		report, err = parseIPSSummary(path)
 	case "hang":
-		return parseHangSummary(path)
+		report, err = parseHangSummary(path)
 	case "spin":
-		return parseSpinSummary(path)
+		report, err = parseSpinSummary(path)
 	case "panic":
-		return parsePanicSummary(path)
+		report, err = parsePanicSummary(path)
+	case "apport":
+		report, err = parseApportSummary(path)
+	case "coredump":
+		report, err = parseCoredumpSummary(path, nil)
+	case "jvm":
+		report, err = parseJVMSummary(path)
 	default:
 		return CrashReport{}, fmt.Errorf("unknown report type: %s", reportType)
 	}
+	if err != nil {
+		return CrashReport{}, err
+	}
+	normalizeTimestamp(&report)
+	return report, nil
 }
 
 // parseIPSSummary parses the header line of an .ips file to extract summary info.
@@ -326,6 +558,7 @@ func parseIPSDetail(path string) (*CrashDetail, error) {
 			Path:       path,
 			ReportType: "crash",
 		},
+		Version:   header.AppVersion,
 		OSVersion: header.OSVersion,
 	}
 
@@ -345,17 +578,13 @@ func parseIPSDetail(path string) (*CrashDetail, error) {
 				}
 			}
 
+			detail.Images = ipsImages(body.UsedImages)
+			detail.Threads = ipsThreads(body, detail.Images)
+
 			// Extract backtrace from the faulting thread.
-			if body.FaultingThread >= 0 && body.FaultingThread < len(body.Threads) {
-				thread := body.Threads[body.FaultingThread]
-				for _, frame := range thread.Frames {
-					if frame.Symbol != "" {
-						detail.Backtrace = append(detail.Backtrace,
-							fmt.Sprintf("%s+%d", frame.Symbol, frame.SymbolLocation))
-					} else {
-						detail.Backtrace = append(detail.Backtrace,
-							fmt.Sprintf("0x%x", frame.ImageOffset))
-					}
+			if body.FaultingThread >= 0 && body.FaultingThread < len(detail.Threads) {
+				for _, frame := range detail.Threads[body.FaultingThread].Frames {
+					detail.Backtrace = append(detail.Backtrace, frame.String())
 				}
 			}
 		}
@@ -364,6 +593,57 @@ func parseIPSDetail(path string) (*CrashDetail, error) {
 	return detail, nil
 }
 
+// ipsImages converts the usedImages section of an .ips body. Images without
+// a name are named after the last element of their path.
+func ipsImages(used []ipsImage) []BinaryImage {
+	images := make([]BinaryImage, len(used))
+	for i, img := range used {
+		name := img.Name
+		if name == "" && img.Path != "" {
+			name = filepath.Base(img.Path)
+		}
+		images[i] = BinaryImage{
+			Index: i,
+			Name:  name,
+			Path:  img.Path,
+			UUID:  img.UUID,
+			Base:  img.Base,
+			Size:  img.Size,
+		}
+	}
+	return images
+}
+
+// ipsThreads converts the threads of an .ips body, resolving each frame's
+// image index against images.
+func ipsThreads(body ipsBody, images []BinaryImage) []CrashThread {
+	threads := make([]CrashThread, len(body.Threads))
+	for i, t := range body.Threads {
+		thread := CrashThread{
+			Index:     i,
+			Name:      t.Name,
+			Queue:     t.Queue,
+			Triggered: t.Triggered || i == body.FaultingThread,
+			Frames:    make([]CrashFrame, len(t.Frames)),
+		}
+		for j, f := range t.Frames {
+			frame := CrashFrame{
+				ImageOffset:  f.ImageOffset,
+				Symbol:       f.Symbol,
+				SymbolOffset: f.SymbolLocation,
+			}
+			if f.ImageIndex != nil && *f.ImageIndex >= 0 && *f.ImageIndex < len(images) {
+				img := images[*f.ImageIndex]
+				frame.Image = img.Name
+				frame.Address = img.Base + f.ImageOffset
+			}
+			thread.Frames[j] = frame
+		}
+		threads[i] = thread
+	}
+	return threads
+}
+
 // hangHeaderRegex matches key-value pairs in .hang file headers.
 var hangProcessRegex = regexp.MustCompile(`(?i)^Process:\s+(.+?)(?:\s+\[(\d+)\])?$`)
 var hangDateRegex = regexp.MustCompile(`(?i)^Date\/Time:\s+(.+)$`)
@@ -452,11 +732,15 @@ func parseTextReportDetail(path string, reportType string) (*CrashDetail, error)
 		},
 	}
 
+	var lines []string
 	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 	for scanner.Scan() {
 		line := scanner.Text()
+		lines = append(lines, line)
 
-		if m := hangProcessRegex.FindStringSubmatch(line); m != nil {
+		// Spindumps list other processes after the target; keep the first.
+		if m := hangProcessRegex.FindStringSubmatch(line); m != nil && detail.Process == "" {
 			detail.Process = strings.TrimSpace(m[1])
 			if len(m) > 2 && m[2] != "" {
 				pid, err := strconv.Atoi(m[2])
@@ -479,5 +763,11 @@ func parseTextReportDetail(path string, reportType string) (*CrashDetail, error)
 		}
 	}
 
+	if reportType == "hang" || reportType == "spin" {
+		if detail.Hang = parseHangInfo(lines); detail.Hang != nil {
+			detail.Backtrace = hangBacktrace(detail.Hang)
+		}
+	}
+
 	return detail, nil
 }
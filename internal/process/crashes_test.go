@@ -70,9 +70,31 @@ func TestParseDuration(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "30 minutes",
+			input: "30m",
+			check: func(t *testing.T, cutoff time.Time) {
+				expected := time.Now().Add(-30 * time.Minute)
+				diff := cutoff.Sub(expected)
+				if diff < -time.Second || diff > time.Second {
+					t.Errorf("cutoff %v not within 1s of expected %v", cutoff, expected)
+				}
+			},
+		},
+		{
+			name:  "2 weeks",
+			input: "2w",
+			check: func(t *testing.T, cutoff time.Time) {
+				expected := time.Now().AddDate(0, 0, -14)
+				diff := cutoff.Sub(expected)
+				if diff < -time.Second || diff > time.Second {
+					t.Errorf("cutoff %v not within 1s of expected %v", cutoff, expected)
+				}
+			},
+		},
 		{
 			name:    "invalid unit",
-			input:   "7m",
+			input:   "7y",
 			wantErr: true,
 		},
 		{
@@ -603,3 +625,148 @@ func TestParseIPSSummary_AppNameFallback(t *testing.T) {
 		t.Errorf("Process = %q, want %q", report.Process, "FallbackApp")
 	}
 }
+
+func TestParseIPSDetail_UsedImages(t *testing.T) {
+	header := `{"app_name":"StrippedApp","timestamp":"2026-02-08 10:30:00.00 -0500","name":"StrippedApp"}`
+	body := `{
+  "pid": 42,
+  "faultingThread": 1,
+  "exception": {"type": "EXC_BAD_ACCESS", "signal": "SIGSEGV"},
+  "usedImages": [
+    {"name": "StrippedApp", "path": "/Applications/StrippedApp.app/Contents/MacOS/StrippedApp", "uuid": "AAAA-1111", "base": 4294967296, "size": 65536},
+    {"path": "/usr/lib/system/libsystem_kernel.dylib", "uuid": "BBBB-2222", "base": 6442450944, "size": 4096}
+  ],
+  "threads": [
+    {"queue": "com.apple.main-thread", "frames": [{"symbol": "mach_msg2_trap", "symbolLocation": 8, "imageOffset": 4660, "imageIndex": 1}]},
+    {"name": "worker", "triggered": true, "frames": [
+      {"imageOffset": 300, "imageIndex": 0},
+      {"imageOffset": 512}
+    ]}
+  ]
+}`
+
+	path := filepath.Join(t.TempDir(), "stripped.ips")
+	if err := os.WriteFile(path, []byte(header+"\n"+body), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	detail, err := parseIPSDetail(path)
+	if err != nil {
+		t.Fatalf("parseIPSDetail() error: %v", err)
+	}
+
+	wantBacktrace := []string{"StrippedApp + 0x12c", "0x200"}
+	if len(detail.Backtrace) != len(wantBacktrace) {
+		t.Fatalf("Backtrace = %q, want %q", detail.Backtrace, wantBacktrace)
+	}
+	for i, want := range wantBacktrace {
+		if detail.Backtrace[i] != want {
+			t.Errorf("Backtrace[%d] = %q, want %q", i, detail.Backtrace[i], want)
+		}
+	}
+
+	if len(detail.Images) != 2 {
+		t.Fatalf("Images length = %d, want 2", len(detail.Images))
+	}
+	if detail.Images[1].Name != "libsystem_kernel.dylib" {
+		t.Errorf("Images[1].Name = %q, want name derived from path", detail.Images[1].Name)
+	}
+	if detail.Images[0].UUID != "AAAA-1111" || detail.Images[0].Base != 0x100000000 {
+		t.Errorf("Images[0] = %+v, want UUID AAAA-1111 at 0x100000000", detail.Images[0])
+	}
+
+	if len(detail.Threads) != 2 {
+		t.Fatalf("Threads length = %d, want 2", len(detail.Threads))
+	}
+	if detail.Threads[0].Triggered || !detail.Threads[1].Triggered {
+		t.Errorf("only thread 1 should be marked as triggered")
+	}
+	if detail.Threads[0].Queue != "com.apple.main-thread" || detail.Threads[1].Name != "worker" {
+		t.Errorf("thread names = %q/%q, want queue and name preserved",
+			detail.Threads[0].Queue, detail.Threads[1].Name)
+	}
+	frame := detail.Threads[1].Frames[0]
+	if frame.Address != 0x100000000+300 {
+		t.Errorf("frame address = 0x%x, want 0x%x", frame.Address, 0x100000000+300)
+	}
+	if got := detail.Threads[0].Frames[0].String(); got != "mach_msg2_trap+8" {
+		t.Errorf("symbolicated frame = %q, want mach_msg2_trap+8", got)
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2026-02-01T08:00:00Z", want: time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)},
+		{input: "2026-02-01", want: time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)},
+		{input: "2026-02-01 14:30", want: time.Date(2026, 2, 1, 14, 30, 0, 0, time.Local)},
+		{input: "now", want: now},
+		{input: "30m", want: now.Add(-30 * time.Minute)},
+		{input: "2w", want: now.AddDate(0, 0, -14)},
+		{input: "3d ago", want: now.AddDate(0, 0, -3)},
+		{input: "yesterday", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimeBound(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeBound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseTimeBound(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryCrashReports(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	// An .ips crash at base, a hang an hour later in another format, and an
+	// Apport report two hours later.
+	writeIPS(t, dir, "a.ips", "1.0", base, "main")
+	hang := "Process:  HangApp [7]\nDate/Time:  " + base.Add(time.Hour).Format("2006-01-02 15:04:05 -0700") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "b.hang"), []byte(hang), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	apport := "ProblemType: Crash\nDate: " + base.Add(2*time.Hour).Local().Format(time.ANSIC) + "\nExecutablePath: /usr/bin/linuxapp\n"
+	if err := os.WriteFile(filepath.Join(dir, "c.crash"), []byte(apport), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	reports := QueryCrashReports(CrashQuery{Dirs: []string{dir}})
+	if len(reports) != 3 {
+		t.Fatalf("QueryCrashReports() returned %d reports, want 3", len(reports))
+	}
+	wantOrder := []string{"linuxapp", "HangApp", "MyApp"}
+	for i, want := range wantOrder {
+		if reports[i].Process != want {
+			t.Errorf("reports[%d].Process = %q, want %q (newest first)", i, reports[i].Process, want)
+		}
+	}
+	for _, r := range reports {
+		if _, err := time.Parse(time.RFC3339, r.Timestamp); err != nil {
+			t.Errorf("Timestamp %q of %s is not RFC3339", r.Timestamp, r.Process)
+		}
+		if r.RawTimestamp == "" || r.RawTimestamp == r.Timestamp {
+			t.Errorf("RawTimestamp of %s = %q, want the source value", r.Process, r.RawTimestamp)
+		}
+	}
+
+	window := QueryCrashReports(CrashQuery{
+		Dirs:  []string{dir},
+		Since: base.Add(30 * time.Minute),
+		Until: base.Add(90 * time.Minute),
+	})
+	if len(window) != 1 || window[0].Process != "HangApp" {
+		t.Errorf("QueryCrashReports(since, until) = %+v, want only HangApp", window)
+	}
+}
@@ -0,0 +1,165 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// maxFollowAttempts is how many polls a new report may fail to parse before
+// it is given up on.
+const maxFollowAttempts = 5
+
+// pendingReport is a new report file that has not been parsed yet.
+type pendingReport struct {
+	size     int64
+	attempts int
+}
+
+// followedLog is the state of a log file searched for runtime crashes.
+type followedLog struct {
+	size    int64        // size at the last poll
+	parsed  int64        // size when last parsed
+	crashes map[int]bool // lines of crashes already reported
+}
+
+// CrashFollower detects report files that appear in a set of directories,
+// and crashes appended to log files matching a set of glob patterns.
+type CrashFollower struct {
+	dirs    []string
+	logs    []string
+	seen    map[string]bool
+	pending map[string]*pendingReport
+	logFile map[string]*followedLog
+}
+
+// NewCrashFollower returns a follower for dirs and the log files matching
+// logs. Reports and crashes that already exist are not returned by Poll.
+func NewCrashFollower(dirs, logs []string) *CrashFollower {
+	f := &CrashFollower{
+		dirs:    dirs,
+		logs:    logs,
+		seen:    make(map[string]bool),
+		pending: make(map[string]*pendingReport),
+		logFile: make(map[string]*followedLog),
+	}
+	for _, path := range f.scan() {
+		f.seen[path] = true
+	}
+	for _, path := range CrashLogFiles(logs) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		l := &followedLog{size: info.Size(), parsed: info.Size(), crashes: make(map[int]bool)}
+		if details, err := parseCrashLog(path); err == nil {
+			for _, d := range details {
+				l.crashes[d.Line] = true
+			}
+		}
+		f.logFile[path] = l
+	}
+	return f
+}
+
+// pollLogs returns crashes added to the log files since they were last
+// parsed. Like report files, a log is only parsed once its size is unchanged
+// between two polls. A log that shrank was rotated or truncated, and all of
+// its crashes are new.
+func (f *CrashFollower) pollLogs() []CrashReport {
+	var reports []CrashReport
+	for _, path := range CrashLogFiles(f.logs) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		l, ok := f.logFile[path]
+		if !ok {
+			f.logFile[path] = &followedLog{size: info.Size(), crashes: make(map[int]bool)}
+			continue
+		}
+		if info.Size() != l.size {
+			l.size = info.Size()
+			continue
+		}
+		if l.size == l.parsed {
+			continue
+		}
+		if l.size < l.parsed {
+			l.crashes = make(map[int]bool)
+		}
+		l.parsed = l.size
+
+		details, err := parseCrashLog(path)
+		if err != nil {
+			continue
+		}
+		for _, d := range details {
+			if !l.crashes[d.Line] {
+				l.crashes[d.Line] = true
+				reports = append(reports, d.CrashReport)
+			}
+		}
+	}
+	return reports
+}
+
+// scan returns the paths of all recognised report files in the directories.
+func (f *CrashFollower) scan() []string {
+	var paths []string
+	for _, dir := range f.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || crashReportType(entry.Name()) == "" {
+				continue
+			}
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths
+}
+
+// Poll returns reports that appeared since the previous call. A new file is
+// only parsed once its size is unchanged between two polls, so reports that
+// are still being written are picked up on a later poll. Reports are
+// returned oldest first.
+func (f *CrashFollower) Poll() []CrashReport {
+	var reports []CrashReport
+	for _, path := range f.scan() {
+		if f.seen[path] {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		p, ok := f.pending[path]
+		if !ok || p.size != info.Size() {
+			f.pending[path] = &pendingReport{size: info.Size()}
+			continue
+		}
+
+		report, err := parseCrashSummary(path, crashReportType(filepath.Base(path)))
+		if err != nil {
+			if p.attempts++; p.attempts < maxFollowAttempts {
+				continue
+			}
+		} else {
+			reports = append(reports, report)
+		}
+		delete(f.pending, path)
+		f.seen[path] = true
+	}
+
+	reports = append(reports, f.pollLogs()...)
+
+	addCoredumpSignals(reports)
+	sort.SliceStable(reports, func(i, j int) bool {
+		return crashTime(reports[i]).Before(crashTime(reports[j]))
+	})
+	return reports
+}
@@ -0,0 +1,110 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCrashFollower(t *testing.T) {
+	dir := t.TempDir()
+	writeIPS(t, dir, "existing.ips", "1.0", time.Now(), "main")
+
+	f := NewCrashFollower([]string{dir}, nil)
+	if got := f.Poll(); len(got) != 0 {
+		t.Fatalf("Poll() returned existing reports: %+v", got)
+	}
+
+	path := writeIPS(t, dir, "new.ips", "1.0", time.Now(), "main")
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	// The first sighting only records the size.
+	if got := f.Poll(); len(got) != 0 {
+		t.Fatalf("Poll() returned %d reports before the file settled, want 0", len(got))
+	}
+	got := f.Poll()
+	if len(got) != 1 || got[0].Path != path || got[0].Process != "MyApp" {
+		t.Fatalf("Poll() = %+v, want the new report", got)
+	}
+	if got := f.Poll(); len(got) != 0 {
+		t.Errorf("Poll() returned %d reports again, want 0", len(got))
+	}
+}
+
+func TestCrashFollower_PartialWrite(t *testing.T) {
+	dir := t.TempDir()
+	f := NewCrashFollower([]string{dir}, nil)
+
+	path := filepath.Join(dir, "partial.ips")
+	if err := os.WriteFile(path, []byte(`{"app_name":"Slow"`), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	f.Poll()
+	if got := f.Poll(); len(got) != 0 {
+		t.Fatalf("Poll() parsed an incomplete report: %+v", got)
+	}
+
+	// Finish writing; the grown file is parsed once it settles again.
+	header := `{"app_name":"Slow","timestamp":"2026-02-10 12:00:00.00 +0000","name":"Slow"}`
+	if err := os.WriteFile(path, []byte(header+"\n{\"pid\": 7}"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	f.Poll()
+	got := f.Poll()
+	if len(got) != 1 || got[0].PID != 7 {
+		t.Errorf("Poll() = %+v, want the completed report", got)
+	}
+}
+
+func TestCrashFollower_GivesUp(t *testing.T) {
+	dir := t.TempDir()
+	f := NewCrashFollower([]string{dir}, nil)
+	if err := os.WriteFile(filepath.Join(dir, "broken.ips"), []byte("not json"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	for i := 0; i <= maxFollowAttempts; i++ {
+		if got := f.Poll(); len(got) != 0 {
+			t.Fatalf("Poll() returned a report for an unparseable file: %+v", got)
+		}
+	}
+	if len(f.pending) != 0 {
+		t.Errorf("pending = %d files, want the broken report dropped", len(f.pending))
+	}
+}
+
+func TestCrashFollower_Logs(t *testing.T) {
+	dir := t.TempDir()
+	path := writeLog(t, dir, "jobs.log", samplePythonLog)
+
+	f := NewCrashFollower(nil, []string{filepath.Join(dir, "*.log")})
+	if got := f.Poll(); len(got) != 0 {
+		t.Fatalf("Poll() returned existing crashes: %+v", got)
+	}
+
+	// Append a second traceback; it is reported once the log settles.
+	appended := samplePythonLog + "Traceback (most recent call last):\n  File \"/srv/jobs/worker.py\", line 5, in <module>\n    main()\nSystemExit: 3\n"
+	if err := os.WriteFile(path, []byte(appended), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Poll(); len(got) != 0 {
+		t.Fatalf("Poll() returned %d crashes before the log settled, want 0", len(got))
+	}
+	got := f.Poll()
+	if len(got) != 1 || got[0].ExceptType != "SystemExit" || got[0].Line != 19 {
+		t.Fatalf("Poll() = %+v, want only the appended traceback", got)
+	}
+	if got := f.Poll(); len(got) != 0 {
+		t.Errorf("Poll() returned %d crashes again, want 0", len(got))
+	}
+
+	// A new log file matching the glob is followed too.
+	writeLog(t, dir, "api.log", sampleGoLog)
+	f.Poll()
+	if got := f.Poll(); len(got) != 2 {
+		t.Errorf("Poll() returned %d crashes from a new log, want 2", len(got))
+	}
+}
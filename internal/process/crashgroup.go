@@ -0,0 +1,162 @@
+package process
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CrashGroup is a set of crash reports that share a signature.
+type CrashGroup struct {
+	Signature  string    `json:"signature"`
+	Process    string    `json:"process"`
+	ExceptType string    `json:"exception_type"`
+	Signal     string    `json:"signal"`
+	TopFrames  []string  `json:"top_frames"`
+	Count      int       `json:"count"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+	Versions   []string  `json:"versions"`
+	SamplePath string    `json:"sample_path"`
+}
+
+// CrashDay is the number of crashes on one calendar day.
+type CrashDay struct {
+	Date  string `json:"date"` // YYYY-MM-DD, local time
+	Count int    `json:"count"`
+}
+
+// symbolOffsetRegex matches the "+123" or " + 123" suffix of a symbolicated
+// frame.
+var symbolOffsetRegex = regexp.MustCompile(`\s*\+\s*\d+$`)
+
+// normalizeFrame strips the parts of a backtrace frame that vary between
+// otherwise identical crashes: symbol offsets and Apport argument lists.
+func normalizeFrame(frame string) string {
+	if i := strings.Index(frame, " ("); i > 0 {
+		frame = frame[:i]
+	}
+	return symbolOffsetRegex.ReplaceAllString(strings.TrimSpace(frame), "")
+}
+
+// topFrames returns the first n normalized frames of a backtrace.
+func topFrames(backtrace []string, n int) []string {
+	if n > len(backtrace) {
+		n = len(backtrace)
+	}
+	frames := make([]string, 0, n)
+	for _, f := range backtrace[:n] {
+		frames = append(frames, normalizeFrame(f))
+	}
+	return frames
+}
+
+// CrashSignature returns a short stable identifier for a crash, computed from
+// the process name, exception type, signal, and the top frames of the
+// faulting thread's backtrace.
+func CrashSignature(detail *CrashDetail, frames int) string {
+	parts := append([]string{detail.Process, detail.ExceptType, detail.Signal},
+		topFrames(detail.Backtrace, frames)...)
+	sum := sha1.Sum([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:6])
+}
+
+// GroupCrashReports groups reports by CrashSignature, reading each report's
+// details to obtain its backtrace. Reports that cannot be read in detail are
+// grouped by their summary alone. Groups are ordered by count, then by most
+// recent occurrence.
+func GroupCrashReports(reports []CrashReport, frames int) []CrashGroup {
+	groups := make(map[string]*CrashGroup)
+	versions := make(map[string]map[string]bool)
+
+	for _, r := range reports {
+		detail, err := GetCrashDetail(r.Location())
+		if err != nil {
+			detail = &CrashDetail{CrashReport: r}
+		}
+		// Summaries may carry fields the detail parser does not, such as
+		// systemd-coredump signals from the journal.
+		if detail.Signal == "" {
+			detail.Signal = r.Signal
+		}
+
+		sig := CrashSignature(detail, frames)
+		g, ok := groups[sig]
+		if !ok {
+			g = &CrashGroup{
+				Signature:  sig,
+				Process:    detail.Process,
+				ExceptType: detail.ExceptType,
+				Signal:     detail.Signal,
+				TopFrames:  topFrames(detail.Backtrace, frames),
+				SamplePath: r.Location(),
+			}
+			groups[sig] = g
+			versions[sig] = make(map[string]bool)
+		}
+		g.Count++
+
+		if ts, err := parseCrashTimestamp(r.Timestamp); err == nil {
+			if g.FirstSeen.IsZero() || ts.Before(g.FirstSeen) {
+				g.FirstSeen = ts
+			}
+			if ts.After(g.LastSeen) {
+				g.LastSeen = ts
+				g.SamplePath = r.Location()
+			}
+		}
+		if detail.Version != "" && !versions[sig][detail.Version] {
+			versions[sig][detail.Version] = true
+			g.Versions = append(g.Versions, detail.Version)
+		}
+	}
+
+	result := make([]CrashGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Versions)
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+	return result
+}
+
+// DailyCrashCounts returns the number of reports per local calendar day,
+// from the earliest to the latest report, including days with no crashes.
+// Reports with unparseable timestamps are skipped.
+func DailyCrashCounts(reports []CrashReport) []CrashDay {
+	counts := make(map[string]int)
+	var first, last time.Time
+	for _, r := range reports {
+		ts, err := parseCrashTimestamp(r.Timestamp)
+		if err != nil {
+			continue
+		}
+		ts = ts.Local()
+		day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+		counts[day.Format("2006-01-02")]++
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	var days []CrashDay
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		days = append(days, CrashDay{Date: date, Count: counts[date]})
+	}
+	return days
+}
@@ -0,0 +1,157 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeIPS writes a minimal .ips report whose faulting thread has the given
+// symbols.
+func writeIPS(t *testing.T, dir, name, version string, ts time.Time, symbols ...string) string {
+	t.Helper()
+	header := fmt.Sprintf(`{"app_name":"MyApp","app_version":%q,"timestamp":%q,"name":"MyApp"}`,
+		version, ts.Format("2006-01-02 15:04:05.00 -0700"))
+	frames := ""
+	for i, sym := range symbols {
+		if i > 0 {
+			frames += ","
+		}
+		frames += fmt.Sprintf(`{"symbol":%q,"symbolLocation":%d,"imageOffset":4096}`, sym, 10*(i+1)+len(name))
+	}
+	body := fmt.Sprintf(`{"pid":%d,"faultingThread":0,"exception":{"type":"EXC_BAD_ACCESS","signal":"SIGSEGV"},"threads":[{"triggered":true,"frames":[%s]}]}`,
+		len(name), frames)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(header+"\n"+body), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestNormalizeFrame(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"objc_msgSend+32", "objc_msgSend"},
+		{"0x12c", "0x12c"},
+		{"handle_request (req=0x5581) at server.c:42", "handle_request"},
+		{"  main+0  ", "main"},
+		{"main + 64 (HangApp + 12345) [0x100abc123]", "main"},
+		{"MyApp + 0x12c", "MyApp + 0x12c"},
+	}
+	for _, tt := range tests {
+		if got := normalizeFrame(tt.in); got != tt.want {
+			t.Errorf("normalizeFrame(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCrashSignature(t *testing.T) {
+	a := &CrashDetail{
+		CrashReport: CrashReport{Process: "MyApp", ExceptType: "EXC_BAD_ACCESS", Signal: "SIGSEGV"},
+		Backtrace:   []string{"objc_msgSend+32", "-[View draw]+100", "main+12"},
+	}
+	b := &CrashDetail{
+		CrashReport: a.CrashReport,
+		Backtrace:   []string{"objc_msgSend+16", "-[View draw]+8", "start+4"},
+	}
+
+	if CrashSignature(a, 2) != CrashSignature(b, 2) {
+		t.Error("signatures should match when the top frames differ only in offsets")
+	}
+	if CrashSignature(a, 3) == CrashSignature(b, 3) {
+		t.Error("signatures should differ when a compared frame differs")
+	}
+
+	c := &CrashDetail{CrashReport: a.CrashReport, Backtrace: a.Backtrace}
+	c.Signal = "SIGBUS"
+	if CrashSignature(a, 2) == CrashSignature(c, 2) {
+		t.Error("signatures should differ when the signal differs")
+	}
+}
+
+func TestGroupCrashReports(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	writeIPS(t, dir, "a.ips", "1.0", base, "objc_msgSend", "-[View draw]", "main")
+	writeIPS(t, dir, "b.ips", "1.1", base.Add(time.Hour), "objc_msgSend", "-[View draw]", "main")
+	latest := writeIPS(t, dir, "c.ips", "1.1", base.Add(2*time.Hour), "objc_msgSend", "-[View draw]", "main")
+	other := writeIPS(t, dir, "d.ips", "1.1", base.Add(30*time.Minute), "abort", "-[Model save]", "main")
+
+	reports, err := ListCrashReportsIn([]string{dir}, "100000d", "")
+	if err != nil {
+		t.Fatalf("ListCrashReportsIn() error: %v", err)
+	}
+
+	groups := GroupCrashReports(reports, 3)
+	if len(groups) != 2 {
+		t.Fatalf("GroupCrashReports() returned %d groups, want 2", len(groups))
+	}
+
+	g := groups[0]
+	if g.Count != 3 {
+		t.Errorf("Count = %d, want 3", g.Count)
+	}
+	if !g.FirstSeen.Equal(base) || !g.LastSeen.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("seen = %v..%v, want %v..%v", g.FirstSeen, g.LastSeen, base, base.Add(2*time.Hour))
+	}
+	if len(g.Versions) != 2 || g.Versions[0] != "1.0" || g.Versions[1] != "1.1" {
+		t.Errorf("Versions = %v, want [1.0 1.1]", g.Versions)
+	}
+	if g.SamplePath != latest {
+		t.Errorf("SamplePath = %q, want most recent %q", g.SamplePath, latest)
+	}
+	if len(g.TopFrames) != 3 || g.TopFrames[0] != "objc_msgSend" {
+		t.Errorf("TopFrames = %v, want normalized frames", g.TopFrames)
+	}
+
+	if groups[1].Count != 1 || groups[1].SamplePath != other {
+		t.Errorf("second group = %+v, want the single abort crash", groups[1])
+	}
+
+	// With no frames, all reports share process, exception and signal.
+	if n := len(GroupCrashReports(reports, 0)); n != 1 {
+		t.Errorf("GroupCrashReports(frames=0) returned %d groups, want 1", n)
+	}
+}
+
+func TestGroupCrashReports_UnreadableDetail(t *testing.T) {
+	reports := []CrashReport{
+		{Process: "gone", Signal: "SIGABRT", Path: "/nonexistent/gone.ips", Timestamp: "2026-02-10 12:00:00"},
+		{Process: "gone", Signal: "SIGABRT", Path: "/nonexistent/gone2.ips", Timestamp: "2026-02-10 13:00:00"},
+	}
+	groups := GroupCrashReports(reports, 5)
+	if len(groups) != 1 || groups[0].Count != 2 || groups[0].Signal != "SIGABRT" {
+		t.Errorf("groups = %+v, want one group of 2 from summaries", groups)
+	}
+}
+
+func TestDailyCrashCounts(t *testing.T) {
+	day := time.Date(2026, 2, 10, 12, 0, 0, 0, time.Local)
+	ts := func(t time.Time) string { return t.Format("2006-01-02 15:04:05 -0700") }
+	reports := []CrashReport{
+		{Timestamp: ts(day)},
+		{Timestamp: ts(day.Add(time.Hour))},
+		{Timestamp: ts(day.AddDate(0, 0, 2))},
+		{Timestamp: "garbage"},
+	}
+
+	days := DailyCrashCounts(reports)
+	want := []CrashDay{{"2026-02-10", 2}, {"2026-02-11", 0}, {"2026-02-12", 1}}
+	if len(days) != len(want) {
+		t.Fatalf("DailyCrashCounts() = %v, want %v", days, want)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("days[%d] = %v, want %v", i, days[i], want[i])
+		}
+	}
+
+	if got := DailyCrashCounts(nil); got != nil {
+		t.Errorf("DailyCrashCounts(nil) = %v, want nil", got)
+	}
+}
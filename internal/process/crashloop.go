@@ -0,0 +1,106 @@
+package process
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CrashLoop describes a process that crashed repeatedly and is running again.
+type CrashLoop struct {
+	Process       string    `json:"process"`
+	PID           int       `json:"pid"` // PID of the running instance
+	Crashes       int       `json:"crashes"`
+	FirstCrash    time.Time `json:"first_crash"`
+	LastCrash     time.Time `json:"last_crash"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds float64   `json:"uptime_seconds"`
+}
+
+// Uptime returns how long the running instance has been up.
+func (l CrashLoop) Uptime() time.Duration {
+	return time.Duration(l.UptimeSeconds * float64(time.Second))
+}
+
+// DetectCrashLoops returns processes with at least minCrashes reports in the
+// window before now that have a running instance started after the first of
+// those crashes, i.e. that have been restarted since they began crashing.
+// startTime looks up the start time of a running process. When several
+// instances are running, the most recently started one is reported. Results
+// are ordered by crash count.
+func DetectCrashLoops(reports []CrashReport, procs []Info, startTime func(pid int) (time.Time, error),
+	minCrashes int, window time.Duration, now time.Time) []CrashLoop {
+	type crashes struct {
+		name        string
+		count       int
+		first, last time.Time
+	}
+	byName := make(map[string]*crashes)
+	since := now.Add(-window)
+	for _, r := range reports {
+		ts, err := parseCrashTimestamp(r.Timestamp)
+		if err != nil || ts.Before(since) || ts.After(now) || r.Process == "" {
+			continue
+		}
+		key := strings.ToLower(r.Process)
+		c, ok := byName[key]
+		if !ok {
+			c = &crashes{name: r.Process, first: ts, last: ts}
+			byName[key] = c
+		}
+		c.count++
+		if ts.Before(c.first) {
+			c.first = ts
+		}
+		if ts.After(c.last) {
+			c.last = ts
+		}
+	}
+
+	var loops []CrashLoop
+	for key, c := range byName {
+		if c.count < minCrashes {
+			continue
+		}
+		var best *CrashLoop
+		for _, p := range procs {
+			if !processMatches(p, key) {
+				continue
+			}
+			started, err := startTime(p.PID)
+			if err != nil || started.Before(c.first) {
+				continue
+			}
+			if best == nil || started.After(best.StartedAt) {
+				best = &CrashLoop{
+					Process:       c.name,
+					PID:           p.PID,
+					Crashes:       c.count,
+					FirstCrash:    c.first,
+					LastCrash:     c.last,
+					StartedAt:     started,
+					UptimeSeconds: now.Sub(started).Seconds(),
+				}
+			}
+		}
+		if best != nil {
+			loops = append(loops, *best)
+		}
+	}
+
+	sort.Slice(loops, func(i, j int) bool {
+		if loops[i].Crashes != loops[j].Crashes {
+			return loops[i].Crashes > loops[j].Crashes
+		}
+		return loops[i].Process < loops[j].Process
+	})
+	return loops
+}
+
+// processMatches reports whether p is an instance of the lower-cased crash
+// report process name. The executable name from the command is also checked
+// because Name stops at the first space of the path.
+func processMatches(p Info, name string) bool {
+	return strings.ToLower(p.Name) == name || strings.ToLower(filepath.Base(p.Command)) == name
+}
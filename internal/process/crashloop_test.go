@@ -0,0 +1,81 @@
+package process
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDetectCrashLoops(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+
+	reports := []CrashReport{
+		{Process: "worker", Timestamp: at(50 * time.Minute)},
+		{Process: "worker", Timestamp: at(30 * time.Minute)},
+		{Process: "Worker", Timestamp: at(10 * time.Minute)},
+		{Process: "worker", Timestamp: at(3 * time.Hour)}, // outside the window
+		{Process: "flaky", Timestamp: at(20 * time.Minute)},
+		{Process: "flaky", Timestamp: at(15 * time.Minute)},
+		{Process: "flaky", Timestamp: at(5 * time.Minute)},
+		{Process: "gone", Timestamp: at(20 * time.Minute)},
+		{Process: "gone", Timestamp: at(15 * time.Minute)},
+		{Process: "gone", Timestamp: at(5 * time.Minute)},
+		{Process: "once", Timestamp: at(5 * time.Minute)},
+	}
+	procs := []Info{
+		{PID: 100, Name: "worker", Command: "/usr/bin/worker"},
+		{PID: 101, Name: "worker", Command: "/usr/bin/worker"},
+		{PID: 200, Name: "/Applications/Flaky", Command: "/Applications/Flaky App.app/Contents/MacOS/flaky"},
+		{PID: 300, Name: "once", Command: "once"},
+	}
+	starts := map[int]time.Time{
+		100: now.Add(-2 * time.Hour), // long-running sibling, not restarted
+		101: now.Add(-9 * time.Minute),
+		200: now.Add(-4 * time.Minute),
+		300: now.Add(-4 * time.Minute),
+	}
+	startTime := func(pid int) (time.Time, error) {
+		if t, ok := starts[pid]; ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("no such process")
+	}
+
+	loops := DetectCrashLoops(reports, procs, startTime, 3, time.Hour, now)
+	if len(loops) != 2 {
+		t.Fatalf("DetectCrashLoops() returned %d loops, want 2: %+v", len(loops), loops)
+	}
+
+	byName := make(map[string]CrashLoop)
+	for _, l := range loops {
+		byName[l.Process] = l
+	}
+	w := byName["worker"]
+	if w.PID != 101 || w.Crashes != 3 {
+		t.Errorf("worker loop = %+v, want PID 101 with 3 crashes", w)
+	}
+	if w.Uptime() != 9*time.Minute {
+		t.Errorf("worker uptime = %v, want 9m", w.Uptime())
+	}
+	if !w.LastCrash.Equal(now.Add(-10 * time.Minute)) {
+		t.Errorf("worker LastCrash = %v, want 10m ago", w.LastCrash)
+	}
+	if f := byName["flaky"]; f.PID != 200 {
+		t.Errorf("flaky loop = %+v, want match by executable name", f)
+	}
+}
+
+func TestDetectCrashLoops_NotRestarted(t *testing.T) {
+	now := time.Now()
+	reports := []CrashReport{
+		{Process: "svc", Timestamp: now.Add(-3 * time.Minute).Format(time.RFC3339)},
+		{Process: "svc", Timestamp: now.Add(-2 * time.Minute).Format(time.RFC3339)},
+	}
+	procs := []Info{{PID: 1, Name: "svc"}}
+	started := func(int) (time.Time, error) { return now.Add(-time.Hour), nil }
+
+	if loops := DetectCrashLoops(reports, procs, started, 2, time.Hour, now); len(loops) != 0 {
+		t.Errorf("DetectCrashLoops() = %+v, want none for a process started before the crashes", loops)
+	}
+}
@@ -0,0 +1,209 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// psDetails are the fields read by AddPSDetails.
+type psDetails struct {
+	vsz     int64
+	nice    int
+	elapsed time.Duration
+	tty     string
+}
+
+// AddPSDetails fills in the virtual size, nice value, start time, and
+// terminal of procs. They are read with a separate ps call so that List stays
+// cheap when they are not shown.
+func AddPSDetails(procs []Info) error {
+	out, err := exec.Command("ps", "-eo", "pid=,vsz=,nice=,etime=,tty=").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get process details: %w", err)
+	}
+	now := time.Now()
+	details := parsePSDetails(string(out))
+	for i := range procs {
+		d, ok := details[procs[i].PID]
+		if !ok {
+			continue
+		}
+		procs[i].VSZ = d.vsz
+		procs[i].Nice = d.nice
+		procs[i].Started = now.Add(-d.elapsed).Truncate(time.Second)
+		procs[i].TTY = d.tty
+	}
+	return nil
+}
+
+// parsePSDetails parses the output of `ps -eo pid=,vsz=,nice=,etime=,tty=`.
+// Processes without a terminal have a TTY of "?" or "??", which is reported
+// as empty. A nice value of "-" (real-time scheduling on Linux) is 0.
+func parsePSDetails(out string) map[int]psDetails {
+	details := make(map[int]psDetails)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		var d psDetails
+		d.vsz, _ = strconv.ParseInt(fields[1], 10, 64)
+		d.nice, _ = strconv.Atoi(fields[2])
+		d.elapsed, _ = parseEtime(fields[3])
+		if tty := fields[4]; strings.Trim(tty, "?") != "" {
+			d.tty = tty
+		}
+		details[pid] = d
+	}
+	return details
+}
+
+// parseEtime parses the etime column of ps, "[[dd-]hh:]mm:ss".
+func parseEtime(s string) (time.Duration, error) {
+	var days int
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid elapsed time %q", s)
+		}
+		days, s = n, rest
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid elapsed time %q", s)
+	}
+	var secs int
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, fmt.Errorf("invalid elapsed time %q", s)
+		}
+		secs = secs*60 + n
+	}
+	return time.Duration(days)*24*time.Hour + time.Duration(secs)*time.Second, nil
+}
+
+// AddThreadCounts fills in the number of threads of procs, from /proc on
+// Linux and from `ps -M` elsewhere.
+func AddThreadCounts(procs []Info) error {
+	if _, err := os.Stat("/proc/self/status"); err == nil {
+		for i := range procs {
+			data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", procs[i].PID))
+			if err == nil {
+				procs[i].Threads = parseStatusThreads(string(data))
+			}
+		}
+		return nil
+	}
+
+	out, err := exec.Command("ps", "-A", "-M").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get thread counts: %w", err)
+	}
+	counts := parsePSThreads(string(out))
+	for i := range procs {
+		procs[i].Threads = counts[procs[i].PID]
+	}
+	return nil
+}
+
+// parseStatusThreads returns the Threads field of /proc/<pid>/status.
+func parseStatusThreads(data string) int {
+	for _, line := range strings.Split(data, "\n") {
+		if rest, ok := strings.CutPrefix(line, "Threads:"); ok {
+			n, _ := strconv.Atoi(strings.TrimSpace(rest))
+			return n
+		}
+	}
+	return 0
+}
+
+// parsePSThreads counts the lines per PID of macOS `ps -A -M`, which prints
+// one line per thread. The first line of a process starts with its user;
+// the following thread lines may leave the user out.
+func parsePSThreads(out string) map[int]int {
+	counts := make(map[int]int)
+	lines := strings.Split(out, "\n")
+	for _, line := range lines[min(1, len(lines)):] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil && len(fields) > 1 {
+			pid, err = strconv.Atoi(fields[1])
+		}
+		if err == nil {
+			counts[pid]++
+		}
+	}
+	return counts
+}
+
+// AddListeningPorts fills in the TCP ports procs are listening on.
+func AddListeningPorts(procs []Info) error {
+	out, err := exec.Command("lsof", "-nP", "-iTCP", "-sTCP:LISTEN").Output()
+	if err != nil && len(out) == 0 {
+		// lsof exits with 1 when nothing is listening.
+		return nil
+	}
+	ports := parseListeningPorts(string(out))
+	for i := range procs {
+		procs[i].Ports = ports[procs[i].PID]
+	}
+	return nil
+}
+
+// parseListeningPorts parses the output of `lsof -nP -iTCP -sTCP:LISTEN`
+// into the sorted, distinct ports per PID.
+func parseListeningPorts(out string) map[int][]int {
+	seen := make(map[int]map[int]bool)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 9 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		port := extractPort(line)
+		if port == 0 {
+			continue
+		}
+		if seen[pid] == nil {
+			seen[pid] = make(map[int]bool)
+		}
+		seen[pid][port] = true
+	}
+
+	ports := make(map[int][]int, len(seen))
+	for pid, set := range seen {
+		for p := range set {
+			ports[pid] = append(ports[pid], p)
+		}
+		sort.Ints(ports[pid])
+	}
+	return ports
+}
+
+// AddOpenFileCounts fills in the number of open file descriptors of procs.
+func AddOpenFileCounts(procs []Info) error {
+	usage, err := FDSnapshot()
+	if err != nil {
+		return err
+	}
+	for i := range procs {
+		procs[i].OpenFiles = usage[procs[i].PID].OpenFiles
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package process
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseEtime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"00:07", 7 * time.Second, false},
+		{"12:34", 12*time.Minute + 34*time.Second, false},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second, false},
+		{"3-04:05:06", 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second, false},
+		{"42", 0, true},
+		{"x-01:00", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseEtime(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseEtime(%q) = %v, %v, want %v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParsePSDetails(t *testing.T) {
+	out := `    1 168004   0   3-04:05:06 ?
+  501 4194304  -5        12:34 ttys002
+  502  20480   -        00:07 pts/0
+garbage
+`
+	details := parsePSDetails(out)
+	if len(details) != 3 {
+		t.Fatalf("parsePSDetails() returned %d entries, want 3", len(details))
+	}
+	if d := details[1]; d.vsz != 168004 || d.tty != "" || d.elapsed != 3*24*time.Hour+4*time.Hour+5*time.Minute+6*time.Second {
+		t.Errorf("PID 1 = %+v", d)
+	}
+	if d := details[501]; d.nice != -5 || d.tty != "ttys002" {
+		t.Errorf("PID 501 = %+v, want nice -5 on ttys002", d)
+	}
+	if d := details[502]; d.nice != 0 || d.tty != "pts/0" {
+		t.Errorf("PID 502 = %+v, want nice 0 on pts/0", d)
+	}
+}
+
+func TestParseStatusThreads(t *testing.T) {
+	data := "Name:\tnode\nState:\tS (sleeping)\nThreads:\t11\nSigQ:\t0/63432\n"
+	if got := parseStatusThreads(data); got != 11 {
+		t.Errorf("parseStatusThreads() = %d, want 11", got)
+	}
+}
+
+func TestParsePSThreads(t *testing.T) {
+	out := `USER     PID   TT  %CPU STAT PRI     STIME     UTIME COMMAND
+user     123 s000   0.0 S    31T   0:00.02   0:00.01 -zsh
+         123        0.0 S    31T   0:00.00   0:00.00
+root       1   ??   0.0 S    31T   0:01.00   0:02.00 /sbin/launchd
+root       1        0.0 S    31T   0:00.00   0:00.00
+root       1        0.0 S    31T   0:00.00   0:00.00
+`
+	counts := parsePSThreads(out)
+	if counts[123] != 2 || counts[1] != 3 {
+		t.Errorf("parsePSThreads() = %v, want 123:2 1:3", counts)
+	}
+}
+
+func TestParseListeningPorts(t *testing.T) {
+	out := `COMMAND   PID USER   FD   TYPE             DEVICE SIZE/OFF NODE NAME
+node    12345 user   22u  IPv4 0x1234567890abcdef      0t0  TCP *:3000 (LISTEN)
+node    12345 user   23u  IPv6 0x2234567890abcdef      0t0  TCP [::1]:3000 (LISTEN)
+node    12345 user   24u  IPv4 0x3234567890abcdef      0t0  TCP 127.0.0.1:9229 (LISTEN)
+postgres  678 user    7u  IPv4 0x4234567890abcdef      0t0  TCP 127.0.0.1:5432 (LISTEN)
+`
+	ports := parseListeningPorts(out)
+	if got := ports[12345]; len(got) != 2 || got[0] != 3000 || got[1] != 9229 {
+		t.Errorf("node ports = %v, want [3000 9229]", got)
+	}
+	if got := ports[678]; len(got) != 1 || got[0] != 5432 {
+		t.Errorf("postgres ports = %v, want [5432]", got)
+	}
+}
+
+func TestAddPSDetails(t *testing.T) {
+	procs := []Info{{PID: os.Getpid()}}
+	if err := AddPSDetails(procs); err != nil {
+		t.Fatalf("AddPSDetails() error: %v", err)
+	}
+	if procs[0].VSZ == 0 {
+		t.Error("VSZ = 0, want the virtual size of this process")
+	}
+	if procs[0].Started.IsZero() || time.Since(procs[0].Started) > time.Hour {
+		t.Errorf("Started = %v, want a recent start time", procs[0].Started)
+	}
+}
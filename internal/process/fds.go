@@ -0,0 +1,248 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FDUsage summarizes the open file descriptors of a process.
+type FDUsage struct {
+	PID       int            `json:"pid"`
+	Name      string         `json:"name"`
+	OpenFiles int            `json:"open_files"`
+	Limit     int            `json:"limit"`            // soft RLIMIT_NOFILE, 0 if unknown or unlimited
+	States    map[string]int `json:"states,omitempty"` // TCP connection state counts
+}
+
+// LimitPercent returns OpenFiles as a percentage of Limit, or 0 if the limit
+// is unknown.
+func (u FDUsage) LimitPercent() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return float64(u.OpenFiles) / float64(u.Limit) * 100
+}
+
+// FDSnapshot returns the file descriptor usage of every visible process.
+func FDSnapshot() (map[int]FDUsage, error) {
+	// lsof exits non-zero when it cannot inspect some processes, so only
+	// treat it as a failure if nothing was printed.
+	out, err := exec.Command("lsof", "-n", "-P").Output()
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("failed to run lsof: %w", err)
+	}
+
+	usage := ParseLsofUsage(string(out))
+	for pid, u := range usage {
+		if limit, err := FDLimit(pid); err == nil {
+			u.Limit = limit
+			usage[pid] = u
+		}
+	}
+	return usage, nil
+}
+
+// ParseLsofUsage parses `lsof -n -P` output into per-process usage. Only
+// numbered descriptors are counted; entries such as cwd, txt, and mem are not
+// file descriptors and do not count against the limit.
+func ParseLsofUsage(output string) map[int]FDUsage {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	usage := make(map[int]FDUsage)
+	if len(lines) < 2 {
+		return usage
+	}
+
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		if fd := fields[3]; fd == "" || !unicode.IsDigit(rune(fd[0])) {
+			continue
+		}
+
+		u, ok := usage[pid]
+		if !ok {
+			u = FDUsage{PID: pid, Name: fields[0]}
+		}
+		u.OpenFiles++
+
+		// TCP sockets end with their state, e.g. "(CLOSE_WAIT)".
+		if last := fields[len(fields)-1]; strings.Contains(line, "TCP") &&
+			strings.HasPrefix(last, "(") && strings.HasSuffix(last, ")") {
+			if u.States == nil {
+				u.States = make(map[string]int)
+			}
+			u.States[strings.Trim(last, "()")]++
+		}
+		usage[pid] = u
+	}
+	return usage
+}
+
+// FDLimitsAvailable reports whether the open-file limits of other processes
+// can be read. They are only exposed by /proc, on Linux.
+func FDLimitsAvailable() bool {
+	_, err := os.Stat("/proc/self/limits")
+	return err == nil
+}
+
+// FDLimit returns the soft open-file limit of a process, read from /proc. A
+// result of 0 means unlimited. Other systems do not expose the limit of
+// another process, so FDLimit fails there.
+func FDLimit(pid int) (int, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", pid))
+	if err != nil {
+		if !FDLimitsAvailable() {
+			return 0, fmt.Errorf("open-file limits of other processes are only available on Linux")
+		}
+		return 0, fmt.Errorf("failed to read open-file limit of PID %d: %w", pid, err)
+	}
+	return parseProcLimits(string(data))
+}
+
+// parseProcLimits extracts the soft "Max open files" value from the contents
+// of /proc/<pid>/limits.
+func parseProcLimits(data string) (int, error) {
+	for _, line := range strings.Split(data, "\n") {
+		rest, ok := strings.CutPrefix(line, "Max open files")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			break
+		}
+		if fields[0] == "unlimited" {
+			return 0, nil
+		}
+		return strconv.Atoi(fields[0])
+	}
+	return 0, fmt.Errorf("open file limit not found")
+}
+
+// FDSample is a single observation of a process's descriptors.
+type FDSample struct {
+	Time      time.Time `json:"time"`
+	OpenFiles int       `json:"open_files"`
+	CloseWait int       `json:"close_wait"`
+	TimeWait  int       `json:"time_wait"`
+}
+
+// FDReport describes the descriptor usage of one process over time.
+type FDReport struct {
+	PID          int     `json:"pid"`
+	Name         string  `json:"name"`
+	OpenFiles    int     `json:"open_files"`
+	Limit        int     `json:"limit"`
+	LimitPercent float64 `json:"limit_percent"`
+	GrowthPerMin float64 `json:"growth_per_min"`
+	Confidence   float64 `json:"confidence"`
+	// CloseWaitGrowthPerMin is the trend of the CLOSE_WAIT socket count.
+	CloseWaitGrowthPerMin float64        `json:"close_wait_growth_per_min"`
+	States                map[string]int `json:"states,omitempty"`
+	Flags                 []string       `json:"flags"` // growing, close-wait-growing, near-limit
+	Samples               []FDSample     `json:"samples"`
+}
+
+// fdSeries is the descriptor history of one process.
+type fdSeries struct {
+	last    FDUsage
+	samples []FDSample
+}
+
+// FDTracker accumulates descriptor samples per PID.
+type FDTracker struct {
+	series map[int]*fdSeries
+}
+
+// NewFDTracker returns an empty FDTracker.
+func NewFDTracker() *FDTracker {
+	return &FDTracker{series: make(map[int]*fdSeries)}
+}
+
+// Add records a snapshot taken at t. If a PID has been reused by a different
+// command, its history starts over.
+func (t *FDTracker) Add(usage map[int]FDUsage, at time.Time) {
+	for pid, u := range usage {
+		s, ok := t.series[pid]
+		if !ok || s.last.Name != u.Name {
+			s = &fdSeries{}
+			t.series[pid] = s
+		}
+		s.last = u
+		s.samples = append(s.samples, FDSample{
+			Time:      at,
+			OpenFiles: u.OpenFiles,
+			CloseWait: u.States["CLOSE_WAIT"],
+			TimeWait:  u.States["TIME_WAIT"],
+		})
+	}
+}
+
+// Report returns processes whose descriptor count or CLOSE_WAIT socket count
+// climbed by at least minGrowth per minute with at least minConfidence, or
+// whose latest count is at or above limitPct percent of their soft limit.
+// Results are ordered by limit usage, then growth.
+func (t *FDTracker) Report(minGrowth, minConfidence, limitPct float64) []FDReport {
+	var reports []FDReport
+	for pid, s := range t.series {
+		r := FDReport{
+			PID:          pid,
+			Name:         s.last.Name,
+			OpenFiles:    s.last.OpenFiles,
+			Limit:        s.last.Limit,
+			LimitPercent: s.last.LimitPercent(),
+			States:       s.last.States,
+			Samples:      s.samples,
+		}
+
+		if len(s.samples) >= minLeakSamples {
+			times := make([]time.Time, len(s.samples))
+			values := make([]float64, len(s.samples))
+			closeWait := make([]float64, len(s.samples))
+			for i, sample := range s.samples {
+				times[i] = sample.Time
+				values[i] = float64(sample.OpenFiles)
+				closeWait[i] = float64(sample.CloseWait)
+			}
+			trend := FitTrend(times, values)
+			r.GrowthPerMin = trend.Slope * 60
+			r.Confidence = trend.Confidence()
+			if r.GrowthPerMin >= minGrowth && r.Confidence >= minConfidence {
+				r.Flags = append(r.Flags, "growing")
+			}
+			// Sockets the peer closed but this process never did.
+			cw := FitTrend(times, closeWait)
+			r.CloseWaitGrowthPerMin = cw.Slope * 60
+			if r.CloseWaitGrowthPerMin >= minGrowth && cw.Confidence() >= minConfidence {
+				r.Flags = append(r.Flags, "close-wait-growing")
+			}
+		}
+		if limitPct > 0 && r.Limit > 0 && r.LimitPercent >= limitPct {
+			r.Flags = append(r.Flags, "near-limit")
+		}
+
+		if len(r.Flags) > 0 {
+			reports = append(reports, r)
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].LimitPercent != reports[j].LimitPercent {
+			return reports[i].LimitPercent > reports[j].LimitPercent
+		}
+		return reports[i].GrowthPerMin > reports[j].GrowthPerMin
+	})
+	return reports
+}
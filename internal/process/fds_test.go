@@ -0,0 +1,165 @@
+package process
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseLsofUsage(t *testing.T) {
+	output := `COMMAND   PID USER   FD   TYPE             DEVICE SIZE/OFF     NODE NAME
+node     4242 dev   cwd    DIR                1,4      640  1234567 /Users/dev/app
+node     4242 dev   txt    REG                1,4 85000000  2345678 /usr/local/bin/node
+node     4242 dev     0u   CHR               16,3      0t0     1234 /dev/ttys003
+node     4242 dev    21u  IPv4 0x1234567890abcdef      0t0      TCP 127.0.0.1:3000 (LISTEN)
+node     4242 dev    22u  IPv4 0x1234567890abcdee      0t0      TCP 127.0.0.1:3000->127.0.0.1:51234 (CLOSE_WAIT)
+node     4242 dev    23u  IPv4 0x1234567890abcded      0t0      TCP 127.0.0.1:3000->127.0.0.1:51235 (CLOSE_WAIT)
+node     4242 dev    24u  IPv4 0x1234567890abcdec      0t0      UDP *:5353
+python   5000 dev     3r   REG                1,4     1024  3456789 /tmp/data.txt
+`
+	usage := ParseLsofUsage(output)
+	if len(usage) != 2 {
+		t.Fatalf("ParseLsofUsage() returned %d processes, want 2", len(usage))
+	}
+
+	node := usage[4242]
+	if node.Name != "node" {
+		t.Errorf("Name = %q, want node", node.Name)
+	}
+	// cwd and txt are not descriptors.
+	if node.OpenFiles != 5 {
+		t.Errorf("OpenFiles = %d, want 5", node.OpenFiles)
+	}
+	if node.States["CLOSE_WAIT"] != 2 {
+		t.Errorf("CLOSE_WAIT = %d, want 2", node.States["CLOSE_WAIT"])
+	}
+	if node.States["LISTEN"] != 1 {
+		t.Errorf("LISTEN = %d, want 1", node.States["LISTEN"])
+	}
+	if usage[5000].OpenFiles != 1 {
+		t.Errorf("python OpenFiles = %d, want 1", usage[5000].OpenFiles)
+	}
+}
+
+func TestParseLsofUsageEmpty(t *testing.T) {
+	if usage := ParseLsofUsage(""); len(usage) != 0 {
+		t.Errorf("ParseLsofUsage(\"\") returned %d entries, want 0", len(usage))
+	}
+}
+
+func TestParseProcLimits(t *testing.T) {
+	data := `Limit                     Soft Limit           Hard Limit           Units
+Max cpu time              unlimited            unlimited            seconds
+Max open files            1024                 1048576              files
+`
+	got, err := parseProcLimits(data)
+	if err != nil {
+		t.Fatalf("parseProcLimits() error: %v", err)
+	}
+	if got != 1024 {
+		t.Errorf("parseProcLimits() = %d, want 1024", got)
+	}
+
+	got, err = parseProcLimits("Max open files            unlimited            unlimited            files\n")
+	if err != nil || got != 0 {
+		t.Errorf("parseProcLimits(unlimited) = %d, %v, want 0, nil", got, err)
+	}
+
+	if _, err := parseProcLimits("garbage"); err == nil {
+		t.Error("parseProcLimits(garbage) should return error")
+	}
+}
+
+func TestFDLimit(t *testing.T) {
+	limit, err := FDLimit(os.Getpid())
+	if !FDLimitsAvailable() {
+		// The limit of another process must not be guessed from ours.
+		if err == nil {
+			t.Errorf("FDLimit() = %d, want an error where limits are not exposed", limit)
+		}
+		return
+	}
+	if err != nil || limit < 0 {
+		t.Errorf("FDLimit(self) = %d, %v, want a limit", limit, err)
+	}
+	if _, err := FDLimit(-1); err == nil {
+		t.Error("FDLimit(-1) should fail")
+	}
+}
+
+func TestFDUsageLimitPercent(t *testing.T) {
+	if got := (FDUsage{OpenFiles: 200, Limit: 256}).LimitPercent(); got != 78.125 {
+		t.Errorf("LimitPercent() = %f, want 78.125", got)
+	}
+	if got := (FDUsage{OpenFiles: 200}).LimitPercent(); got != 0 {
+		t.Errorf("LimitPercent() with unknown limit = %f, want 0", got)
+	}
+}
+
+func TestFDTrackerReport(t *testing.T) {
+	tracker := NewFDTracker()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		tracker.Add(map[int]FDUsage{
+			// Leaks 10 descriptors a minute.
+			1: {PID: 1, Name: "leaky", OpenFiles: 100 + i*10, Limit: 10240,
+				States: map[string]int{"CLOSE_WAIT": i}},
+			// Flat but close to its limit.
+			2: {PID: 2, Name: "full", OpenFiles: 240, Limit: 256},
+			// Flat and far from its limit.
+			3: {PID: 3, Name: "calm", OpenFiles: 20, Limit: 256},
+		}, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	reports := tracker.Report(5, 0.6, 80)
+	if len(reports) != 2 {
+		t.Fatalf("Report() returned %d reports, want 2: %+v", len(reports), reports)
+	}
+
+	// Sorted by limit usage first.
+	if reports[0].PID != 2 || reports[0].Flags[0] != "near-limit" {
+		t.Errorf("reports[0] = PID %d %v, want PID 2 near-limit", reports[0].PID, reports[0].Flags)
+	}
+	leaky := reports[1]
+	if leaky.PID != 1 || leaky.Flags[0] != "growing" {
+		t.Errorf("reports[1] = PID %d %v, want PID 1 growing", leaky.PID, leaky.Flags)
+	}
+	if leaky.GrowthPerMin < 9.99 || leaky.GrowthPerMin > 10.01 {
+		t.Errorf("GrowthPerMin = %f, want 10", leaky.GrowthPerMin)
+	}
+	if leaky.Samples[5].CloseWait != 5 {
+		t.Errorf("last CloseWait = %d, want 5", leaky.Samples[5].CloseWait)
+	}
+}
+
+func TestFDTrackerReportCloseWait(t *testing.T) {
+	tracker := NewFDTracker()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		tracker.Add(map[int]FDUsage{
+			// Descriptor count is flat, but closed sockets pile up in CLOSE_WAIT.
+			1: {PID: 1, Name: "stuck", OpenFiles: 200, Limit: 10240,
+				States: map[string]int{"CLOSE_WAIT": i * 8}},
+			// CLOSE_WAIT bounces around without a trend.
+			2: {PID: 2, Name: "busy", OpenFiles: 200, Limit: 10240,
+				States: map[string]int{"CLOSE_WAIT": []int{3, 9, 2, 8, 3, 9}[i]}},
+		}, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	reports := tracker.Report(5, 0.6, 80)
+	if len(reports) != 1 {
+		t.Fatalf("Report() returned %d reports, want 1: %+v", len(reports), reports)
+	}
+	stuck := reports[0]
+	if stuck.PID != 1 || len(stuck.Flags) != 1 || stuck.Flags[0] != "close-wait-growing" {
+		t.Errorf("reports[0] = PID %d %v, want PID 1 [close-wait-growing]", stuck.PID, stuck.Flags)
+	}
+	if stuck.CloseWaitGrowthPerMin < 7.99 || stuck.CloseWaitGrowthPerMin > 8.01 {
+		t.Errorf("CloseWaitGrowthPerMin = %f, want 8", stuck.CloseWaitGrowthPerMin)
+	}
+	if stuck.GrowthPerMin != 0 {
+		t.Errorf("GrowthPerMin = %f, want 0", stuck.GrowthPerMin)
+	}
+}
@@ -0,0 +1,200 @@
+package process
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HangInfo holds the sampled call trees of a .hang or .spin (spindump)
+// report.
+type HangInfo struct {
+	Duration        string       `json:"duration,omitempty"`
+	DurationSampled string       `json:"duration_sampled,omitempty"`
+	Steps           int          `json:"steps,omitempty"`
+	HeaviestStack   []CallNode   `json:"heaviest_stack,omitempty"` // outermost frame first
+	Threads         []HangThread `json:"threads,omitempty"`
+}
+
+// HangThread is the sampled call tree of one thread of the hung process.
+type HangThread struct {
+	ID      string      `json:"id"`
+	Queue   string      `json:"queue,omitempty"`
+	Samples int         `json:"samples"`
+	Calls   []*CallNode `json:"calls,omitempty"`
+}
+
+// CallNode is a frame of a sampled call tree and the number of samples in
+// which it was on the stack.
+type CallNode struct {
+	Count    int         `json:"count"`
+	Frame    string      `json:"frame"`
+	Kernel   bool        `json:"kernel,omitempty"`
+	Children []*CallNode `json:"children,omitempty"`
+}
+
+var (
+	hangSampledRegex = regexp.MustCompile(`(?i)^Duration Sampled:\s+(.+)$`)
+	hangStepsRegex   = regexp.MustCompile(`(?i)^Steps:\s+(\d+)`)
+	hangThreadRegex  = regexp.MustCompile(`^\s+Thread (0x[0-9a-fA-F]+)(.*?)\s+(\d+) samples?\b`)
+	hangQueueRegex   = regexp.MustCompile(`(?:DispatchQueue|Thread name) "([^"]*)"`)
+	hangFrameRegex   = regexp.MustCompile(`^(\s*)(\*?)(\d+)\s+(.+)$`)
+	hangRangeRegex   = regexp.MustCompile(`\s+\d+(?:-\d+)?$`)
+)
+
+// heaviestStackHeader introduces the heaviest stack section of a spindump.
+const heaviestStackHeader = "Heaviest stack for the main thread"
+
+// parseHangInfo parses the sampling sections of a spindump report. Only the
+// threads of the first (target) process are collected. It returns nil if the
+// report has no sampling data.
+func parseHangInfo(lines []string) *HangInfo {
+	info := &HangInfo{}
+	var (
+		inHeaviest bool
+		thread     *HangThread
+		stack      []*callLevel
+		processes  int
+	)
+
+	finishThread := func() {
+		if thread != nil {
+			info.Threads = append(info.Threads, *thread)
+			thread = nil
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "Process:"):
+			finishThread()
+			inHeaviest = false
+			processes++
+			continue
+		case strings.HasPrefix(line, "Binary Images:"):
+			finishThread()
+			inHeaviest = false
+			continue
+		case strings.HasPrefix(line, heaviestStackHeader):
+			inHeaviest = true
+			continue
+		}
+
+		if inHeaviest {
+			if trimmed == "" {
+				inHeaviest = false
+				continue
+			}
+			if m := hangFrameRegex.FindStringSubmatch(line); m != nil {
+				count, _ := strconv.Atoi(m[3])
+				info.HeaviestStack = append(info.HeaviestStack, CallNode{
+					Count:  count,
+					Frame:  strings.TrimSpace(m[4]),
+					Kernel: m[2] == "*",
+				})
+			}
+			continue
+		}
+
+		if m := hangDurationRegex.FindStringSubmatch(line); m != nil && info.Duration == "" {
+			info.Duration = strings.TrimSpace(m[1])
+		}
+		if m := hangSampledRegex.FindStringSubmatch(line); m != nil && info.DurationSampled == "" {
+			info.DurationSampled = strings.TrimSpace(m[1])
+		}
+		if m := hangStepsRegex.FindStringSubmatch(line); m != nil && info.Steps == 0 {
+			info.Steps, _ = strconv.Atoi(m[1])
+		}
+
+		// Threads of processes other than the target are ignored.
+		if processes > 1 {
+			continue
+		}
+
+		if m := hangThreadRegex.FindStringSubmatch(line); m != nil {
+			finishThread()
+			samples, _ := strconv.Atoi(m[3])
+			thread = &HangThread{ID: m[1], Samples: samples}
+			if q := hangQueueRegex.FindStringSubmatch(m[2]); q != nil {
+				thread.Queue = q[1]
+			}
+			stack = stack[:0]
+			continue
+		}
+
+		if thread == nil {
+			continue
+		}
+		if trimmed == "" {
+			finishThread()
+			continue
+		}
+		m := hangFrameRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		count, _ := strconv.Atoi(m[3])
+		node := &CallNode{
+			Count:  count,
+			Frame:  hangRangeRegex.ReplaceAllString(strings.TrimSpace(m[4]), ""),
+			Kernel: m[2] == "*",
+		}
+		// Nesting is given by the column of the sample count.
+		col := len(m[1]) + len(m[2])
+		for len(stack) > 0 && stack[len(stack)-1].col >= col {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			thread.Calls = append(thread.Calls, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, &callLevel{col: col, node: node})
+	}
+	finishThread()
+
+	if len(info.HeaviestStack) == 0 && len(info.Threads) > 0 {
+		info.HeaviestStack = heaviestPath(info.Threads[0].Calls)
+	}
+	if len(info.HeaviestStack) == 0 && len(info.Threads) == 0 {
+		return nil
+	}
+	return info
+}
+
+// callLevel is an open node while a call tree is being parsed.
+type callLevel struct {
+	col  int
+	node *CallNode
+}
+
+// heaviestPath follows the most-sampled child from the root down, for
+// reports without a heaviest stack section.
+func heaviestPath(calls []*CallNode) []CallNode {
+	var path []CallNode
+	for len(calls) > 0 {
+		heaviest := calls[0]
+		for _, c := range calls[1:] {
+			if c.Count > heaviest.Count {
+				heaviest = c
+			}
+		}
+		path = append(path, CallNode{Count: heaviest.Count, Frame: heaviest.Frame, Kernel: heaviest.Kernel})
+		calls = heaviest.Children
+	}
+	return path
+}
+
+// hangBacktrace returns the heaviest stack innermost frame first, matching
+// the order of crash backtraces.
+func hangBacktrace(info *HangInfo) []string {
+	frames := make([]string, 0, len(info.HeaviestStack))
+	for i := len(info.HeaviestStack) - 1; i >= 0; i-- {
+		frames = append(frames, info.HeaviestStack[i].Frame)
+	}
+	return frames
+}
@@ -0,0 +1,138 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const spindumpSample = `Date/Time:        2026-02-10 14:30:00.123 -0500
+End time:         2026-02-10 14:30:05.456 -0500
+OS Version:       macOS 26.2 (Build 25C56)
+Architecture:     arm64
+Command:          HangApp
+Path:             /Applications/HangApp.app/Contents/MacOS/HangApp
+Duration:         5.33s
+Duration Sampled: 3.00s (process was unresponsive for 2 seconds before sampling)
+Steps:            30 (100ms sampling interval)
+
+Heaviest stack for the main thread of the target process:
+  30  start + 2360 (dyld + 24840) [0x19b0b9e08]
+  30  main + 64 (HangApp + 12345) [0x100abc123]
+  25  -[Controller reload] + 40 (HangApp + 20000) [0x100ac0000]
+  25  __psynch_mutexwait + 8 (libsystem_kernel.dylib + 17692) [0x19b3e1234]
+ *25  psynch_mtxcontinue + 0 (pthread + 1234) [0xfffffe000123]
+
+Process:          HangApp [4242]
+UUID:             11111111-2222-3333-4444-555555555555
+Path:             /Applications/HangApp.app/Contents/MacOS/HangApp
+Num samples:      30 (1-30)
+
+  Thread 0x1a2b    DispatchQueue "com.apple.main-thread"(1)    30 samples (1-30)    priority 46 (base 46)
+  30  start + 2360 (dyld + 24840) [0x19b0b9e08] 1-30
+    30  main + 64 (HangApp + 12345) [0x100abc123] 1-30
+      25  -[Controller reload] + 40 (HangApp + 20000) [0x100ac0000] 1-25
+        25  __psynch_mutexwait + 8 (libsystem_kernel.dylib + 17692) [0x19b3e1234] 1-25
+         *25  psynch_mtxcontinue + 0 (pthread + 1234) [0xfffffe000123] 1-25
+      5   -[NSApplication run] + 100 (AppKit + 5000) [0x19f000000] 26-30
+
+  Thread 0x1a2c    Thread name "worker"    30 samples (1-30)    priority 31 (base 31)
+  30  start_wqthread + 8 (libsystem_pthread.dylib + 7024) [0x19b4a0000] 1-30
+    30  __workq_kernreturn + 8 (libsystem_kernel.dylib + 4000) [0x19b3e0000] 1-30
+
+Process:          WindowServer [150]
+Path:             /System/Library/PrivateFrameworks/SkyLight.framework/Resources/WindowServer
+
+  Thread 0x99    30 samples (1-30)    priority 79 (base 79)
+  30  start + 1 (dyld + 1) [0x1] 1-30
+
+Binary Images:
+       0x100ab0000 -        0x100afffff  com.example.HangApp 1.0 (1) <11111111-2222-3333-4444-555555555555> /Applications/HangApp.app/Contents/MacOS/HangApp
+`
+
+func TestParseHangDetail_Spindump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "HangApp.hang")
+	if err := os.WriteFile(path, []byte(spindumpSample), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	detail, err := GetCrashDetail(path)
+	if err != nil {
+		t.Fatalf("GetCrashDetail() error: %v", err)
+	}
+	if detail.Process != "HangApp" || detail.PID != 4242 {
+		t.Errorf("process = %s [%d], want the target process HangApp [4242]", detail.Process, detail.PID)
+	}
+
+	h := detail.Hang
+	if h == nil {
+		t.Fatal("Hang is nil")
+	}
+	if h.Duration != "5.33s" || h.Steps != 30 || !strings.HasPrefix(h.DurationSampled, "3.00s") {
+		t.Errorf("Hang = duration %q, sampled %q, steps %d", h.Duration, h.DurationSampled, h.Steps)
+	}
+
+	if len(h.HeaviestStack) != 5 {
+		t.Fatalf("HeaviestStack has %d frames, want 5", len(h.HeaviestStack))
+	}
+	leaf := h.HeaviestStack[4]
+	if !leaf.Kernel || leaf.Count != 25 || !strings.HasPrefix(leaf.Frame, "psynch_mtxcontinue") {
+		t.Errorf("leaf frame = %+v, want kernel psynch_mtxcontinue with 25 samples", leaf)
+	}
+	if len(detail.Backtrace) != 5 || !strings.HasPrefix(detail.Backtrace[0], "psynch_mtxcontinue") ||
+		!strings.HasPrefix(detail.Backtrace[4], "start") {
+		t.Errorf("Backtrace = %q, want heaviest stack innermost first", detail.Backtrace)
+	}
+
+	if len(h.Threads) != 2 {
+		t.Fatalf("Threads = %d, want 2 (other processes ignored)", len(h.Threads))
+	}
+	main := h.Threads[0]
+	if main.ID != "0x1a2b" || main.Queue != "com.apple.main-thread" || main.Samples != 30 {
+		t.Errorf("main thread = %+v", main)
+	}
+	if h.Threads[1].Queue != "worker" {
+		t.Errorf("worker thread queue = %q, want worker", h.Threads[1].Queue)
+	}
+
+	// start -> main -> {reload -> mutexwait -> *mtxcontinue, NSApplication run}
+	if len(main.Calls) != 1 {
+		t.Fatalf("main thread has %d roots, want 1", len(main.Calls))
+	}
+	mainFn := main.Calls[0].Children[0]
+	if len(mainFn.Children) != 2 {
+		t.Fatalf("main() has %d children, want 2", len(mainFn.Children))
+	}
+	if mainFn.Children[1].Count != 5 || mainFn.Children[1].Frame != "-[NSApplication run] + 100 (AppKit + 5000) [0x19f000000]" {
+		t.Errorf("second child = %+v, want NSApplication run without sample range", mainFn.Children[1])
+	}
+	kernel := mainFn.Children[0].Children[0].Children[0]
+	if !kernel.Kernel || kernel.Count != 25 {
+		t.Errorf("kernel frame = %+v, want kernel frame with 25 samples", kernel)
+	}
+}
+
+func TestParseHangInfo_NoHeaviestStack(t *testing.T) {
+	lines := strings.Split(`Process:          Spinner [1]
+
+  Thread 0x1    30 samples (1-30)
+  30  start
+    20  busy_loop
+    10  idle
+`, "\n")
+
+	info := parseHangInfo(lines)
+	if info == nil {
+		t.Fatal("parseHangInfo() = nil")
+	}
+	if len(info.HeaviestStack) != 2 || info.HeaviestStack[1].Frame != "busy_loop" {
+		t.Errorf("HeaviestStack = %+v, want start -> busy_loop", info.HeaviestStack)
+	}
+}
+
+func TestParseHangInfo_HeaderOnly(t *testing.T) {
+	if info := parseHangInfo([]string{"Process: X [1]", "Duration: 2s"}); info != nil {
+		t.Errorf("parseHangInfo() = %+v, want nil without samples", info)
+	}
+}
@@ -16,6 +16,13 @@ type Connection struct {
 	State      string `json:"state"`
 }
 
+// OpenFile is a file descriptor held by a process, as reported by lsof.
+type OpenFile struct {
+	FD   string `json:"fd"`   // descriptor and access mode (e.g. "3u"), or cwd, txt, mem
+	Type string `json:"type"` // REG, DIR, IPv4, unix, ...
+	Name string `json:"name"`
+}
+
 // DetailedInfo holds extended information about a single process.
 type DetailedInfo struct {
 	PID         int               `json:"pid"`
@@ -24,6 +31,7 @@ type DetailedInfo struct {
 	CPU         float64           `json:"cpu"`
 	Mem         float64           `json:"mem"`
 	OpenFiles   int               `json:"open_files"`
+	Files       []OpenFile        `json:"files,omitempty"`
 	Ports       []int             `json:"ports"`
 	Children    []int             `json:"children"`
 	Connections []Connection      `json:"connections,omitempty"`
@@ -117,6 +125,7 @@ func (d *DetailedInfo) fetchLsofInfo() {
 	}
 
 	d.OpenFiles = fileCount
+	d.Files = ParseLsofFiles(string(out))
 	for p := range portSet {
 		d.Ports = append(d.Ports, p)
 	}
@@ -225,6 +234,35 @@ func ParseLsofConnections(output string) []Connection {
 	return conns
 }
 
+// ParseLsofFiles parses the output of `lsof -p <pid>` into its open files.
+// lsof aligns the NAME column with its header and leaves some columns empty
+// for some file types, so the name is cut at the header's position rather
+// than split on whitespace.
+func ParseLsofFiles(output string) []OpenFile {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	nameCol := strings.Index(lines[0], "NAME")
+	if nameCol < 0 {
+		return nil
+	}
+
+	var files []OpenFile
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || len(line) <= nameCol {
+			continue
+		}
+		files = append(files, OpenFile{
+			FD:   fields[3],
+			Type: fields[4],
+			Name: strings.TrimSpace(line[nameCol:]),
+		})
+	}
+	return files
+}
+
 // ParseEnvVars parses the output of `ps eww -p <pid> -o command=` to extract environment variables.
 func ParseEnvVars(output string) map[string]string {
 	output = strings.TrimSpace(output)
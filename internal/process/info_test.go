@@ -161,6 +161,36 @@ node      12345 user   10u  IPv4 0x1234      0t0  TCP *:8080 (LISTEN)`,
 	}
 }
 
+func TestParseLsofFiles(t *testing.T) {
+	output := `COMMAND   PID USER   FD      TYPE             DEVICE SIZE/OFF    NODE NAME
+node    12345 user  cwd       DIR               1,16      640 2345678 /Users/user/my app
+node    12345 user  txt       REG               1,16 98765432 3456789 /usr/local/bin/node
+node    12345 user    0u      CHR               16,2   0t1234    1235 /dev/ttys002
+node    12345 user   10u     IPv4 0x1234567890abcdef      0t0     TCP *:8080 (LISTEN)
+node    12345 user   11u     unix 0x2345678901bcdef0      0t0         ->0x3456789012cdef01
+`
+	files := ParseLsofFiles(output)
+	if len(files) != 5 {
+		t.Fatalf("ParseLsofFiles() returned %d files, want 5", len(files))
+	}
+	want := []OpenFile{
+		{FD: "cwd", Type: "DIR", Name: "/Users/user/my app"},
+		{FD: "txt", Type: "REG", Name: "/usr/local/bin/node"},
+		{FD: "0u", Type: "CHR", Name: "/dev/ttys002"},
+		{FD: "10u", Type: "IPv4", Name: "*:8080 (LISTEN)"},
+		{FD: "11u", Type: "unix", Name: "->0x3456789012cdef01"},
+	}
+	for i, f := range files {
+		if f != want[i] {
+			t.Errorf("files[%d] = %+v, want %+v", i, f, want[i])
+		}
+	}
+
+	if got := ParseLsofFiles(""); got != nil {
+		t.Errorf("ParseLsofFiles(\"\") = %+v, want nil", got)
+	}
+}
+
 func TestParseEnvVars(t *testing.T) {
 	tests := []struct {
 		name  string
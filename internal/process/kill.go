@@ -1,8 +1,10 @@
 package process
 
 import (
+	"errors"
 	"fmt"
 	"syscall"
+	"time"
 )
 
 // Kill sends SIGTERM (or SIGKILL if force is true) to the given PID.
@@ -24,3 +26,27 @@ func KillWithSignal(pid int, sig syscall.Signal) error {
 	}
 	return nil
 }
+
+// KillIfStarted sends sig to pid only if the process still has the given
+// start time, so that a signal meant for an exited process is never
+// delivered to an unrelated process that reused its PID.
+func KillIfStarted(pid int, sig syscall.Signal, started time.Time) error {
+	current, err := StartTime(pid)
+	if err != nil {
+		return fmt.Errorf("PID %d is no longer running", pid)
+	}
+	if !current.Equal(started) {
+		return fmt.Errorf("PID %d now belongs to a different process (started %s), not signalled",
+			pid, current.Format("15:04:05"))
+	}
+	return KillWithSignal(pid, sig)
+}
+
+// Alive reports whether a process with the given PID exists.
+func Alive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -1,8 +1,10 @@
 package process
 
 import (
+	"os"
 	"syscall"
 	"testing"
+	"time"
 )
 
 func TestKillInvalidPID(t *testing.T) {
@@ -38,3 +40,34 @@ func TestKillWithSignalNonExistentProcess(t *testing.T) {
 		t.Error("KillWithSignal(999999) should return an error for non-existent process")
 	}
 }
+
+func TestAlive(t *testing.T) {
+	if !Alive(os.Getpid()) {
+		t.Error("Alive(self) = false, want true")
+	}
+	if Alive(999999) {
+		t.Error("Alive(999999) = true, want false")
+	}
+	if Alive(0) {
+		t.Error("Alive(0) = true, want false")
+	}
+}
+
+func TestKillIfStarted(t *testing.T) {
+	pid := os.Getpid()
+	started, err := StartTime(pid)
+	if err != nil {
+		t.Fatalf("StartTime(self) error: %v", err)
+	}
+
+	// Signal 0 only checks that the process can be signalled.
+	if err := KillIfStarted(pid, 0, started); err != nil {
+		t.Errorf("KillIfStarted(matching start) error: %v", err)
+	}
+	if err := KillIfStarted(pid, 0, started.Add(-time.Hour)); err == nil {
+		t.Error("KillIfStarted(different start) should refuse to signal")
+	}
+	if err := KillIfStarted(999999, 0, started); err == nil {
+		t.Error("KillIfStarted(non-existent) should return an error")
+	}
+}
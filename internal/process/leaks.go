@@ -0,0 +1,101 @@
+package process
+
+import (
+	"sort"
+	"time"
+)
+
+// minLeakSamples is the minimum number of samples needed to judge a trend.
+const minLeakSamples = 3
+
+// MemSample is a single RSS observation of a process.
+type MemSample struct {
+	Time time.Time `json:"time"`
+	RSS  int64     `json:"rss_kb"`
+}
+
+// MemLeak describes a process whose memory grew steadily while sampled.
+type MemLeak struct {
+	PID            int         `json:"pid"`
+	Name           string      `json:"name"`
+	Command        string      `json:"command"`
+	StartRSS       int64       `json:"start_rss_kb"`
+	EndRSS         int64       `json:"end_rss_kb"`
+	GrowthMBPerMin float64     `json:"growth_mb_per_min"`
+	Confidence     float64     `json:"confidence"`
+	Trend          Trend       `json:"trend"`
+	Samples        []MemSample `json:"samples"`
+}
+
+// memSeries is the RSS history of one process.
+type memSeries struct {
+	info    Info
+	samples []MemSample
+}
+
+// LeakDetector accumulates RSS samples per PID and reports steady growth.
+type LeakDetector struct {
+	series map[int]*memSeries
+}
+
+// NewLeakDetector returns an empty LeakDetector.
+func NewLeakDetector() *LeakDetector {
+	return &LeakDetector{series: make(map[int]*memSeries)}
+}
+
+// Add records one sample of every process in procs taken at t. If a PID has
+// been reused by a different executable, its history starts over.
+func (d *LeakDetector) Add(procs []Info, t time.Time) {
+	for _, p := range procs {
+		s, ok := d.series[p.PID]
+		if !ok || s.info.Name != p.Name {
+			s = &memSeries{}
+			d.series[p.PID] = s
+		}
+		s.info = p
+		s.samples = append(s.samples, MemSample{Time: t, RSS: p.RSS})
+	}
+}
+
+// Leaks returns processes whose RSS grew by at least minRate MB/min with a
+// trend confidence of at least minConfidence, fastest growth first.
+func (d *LeakDetector) Leaks(minRate, minConfidence float64) []MemLeak {
+	var leaks []MemLeak
+	for pid, s := range d.series {
+		if len(s.samples) < minLeakSamples {
+			continue
+		}
+
+		times := make([]time.Time, len(s.samples))
+		values := make([]float64, len(s.samples))
+		for i, sample := range s.samples {
+			times[i] = sample.Time
+			values[i] = float64(sample.RSS)
+		}
+		trend := FitTrend(times, values)
+
+		// Slope is KB/s; convert to MB/min.
+		rate := trend.Slope * 60 / 1024
+		confidence := trend.Confidence()
+		if rate < minRate || confidence < minConfidence {
+			continue
+		}
+
+		leaks = append(leaks, MemLeak{
+			PID:            pid,
+			Name:           s.info.Name,
+			Command:        s.info.Command,
+			StartRSS:       s.samples[0].RSS,
+			EndRSS:         s.samples[len(s.samples)-1].RSS,
+			GrowthMBPerMin: rate,
+			Confidence:     confidence,
+			Trend:          trend,
+			Samples:        s.samples,
+		})
+	}
+
+	sort.Slice(leaks, func(i, j int) bool {
+		return leaks[i].GrowthMBPerMin > leaks[j].GrowthMBPerMin
+	})
+	return leaks
+}
@@ -0,0 +1,124 @@
+package process
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFitTrend(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := func(n int) []time.Time {
+		ts := make([]time.Time, n)
+		for i := range ts {
+			ts[i] = start.Add(time.Duration(i) * 10 * time.Second)
+		}
+		return ts
+	}
+
+	tests := []struct {
+		name          string
+		values        []float64
+		wantSlope     float64
+		wantR2        float64
+		wantMonotonic float64
+	}{
+		{"linear growth", []float64{0, 10, 20, 30}, 1.0, 1.0, 1.0},
+		{"flat", []float64{5, 5, 5, 5}, 0, 0, 1.0},
+		{"shrinking", []float64{30, 20, 10, 0}, -1.0, 1.0, 0},
+		{"single sample", []float64{1}, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FitTrend(times(len(tt.values)), tt.values)
+			if math.Abs(got.Slope-tt.wantSlope) > 1e-9 {
+				t.Errorf("Slope = %f, want %f", got.Slope, tt.wantSlope)
+			}
+			if math.Abs(got.RSquared-tt.wantR2) > 1e-9 {
+				t.Errorf("RSquared = %f, want %f", got.RSquared, tt.wantR2)
+			}
+			if math.Abs(got.Monotonic-tt.wantMonotonic) > 1e-9 {
+				t.Errorf("Monotonic = %f, want %f", got.Monotonic, tt.wantMonotonic)
+			}
+		})
+	}
+}
+
+func TestTrendConfidence(t *testing.T) {
+	if c := (Trend{Slope: -1, RSquared: 1, Monotonic: 1}).Confidence(); c != 0 {
+		t.Errorf("Confidence() for shrinking trend = %f, want 0", c)
+	}
+	if c := (Trend{Slope: 1, RSquared: 0.5, Monotonic: 0.5}).Confidence(); c != 0.25 {
+		t.Errorf("Confidence() = %f, want 0.25", c)
+	}
+}
+
+func TestLeakDetector(t *testing.T) {
+	d := NewLeakDetector()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 10; i++ {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		d.Add([]Info{
+			// Grows 2 MB every minute.
+			{PID: 1, Name: "leaky", RSS: 100*1024 + int64(i)*2*1024},
+			// Stable.
+			{PID: 2, Name: "steady", RSS: 50 * 1024},
+			// Sawtooth: grows then gets collected.
+			{PID: 3, Name: "gc", RSS: 80*1024 + int64(i%3)*10*1024},
+		}, ts)
+	}
+
+	leaks := d.Leaks(1.0, 0.6)
+	if len(leaks) != 1 {
+		t.Fatalf("Leaks() returned %d leaks, want 1: %+v", len(leaks), leaks)
+	}
+	l := leaks[0]
+	if l.PID != 1 || l.Name != "leaky" {
+		t.Errorf("leak = PID %d %q, want PID 1 leaky", l.PID, l.Name)
+	}
+	if math.Abs(l.GrowthMBPerMin-2.0) > 1e-6 {
+		t.Errorf("GrowthMBPerMin = %f, want 2.0", l.GrowthMBPerMin)
+	}
+	if l.Confidence < 0.99 {
+		t.Errorf("Confidence = %f, want ~1", l.Confidence)
+	}
+	if len(l.Samples) != 10 {
+		t.Errorf("Samples = %d, want 10", len(l.Samples))
+	}
+	if l.StartRSS != 100*1024 || l.EndRSS != 118*1024 {
+		t.Errorf("StartRSS/EndRSS = %d/%d, want %d/%d", l.StartRSS, l.EndRSS, 100*1024, 118*1024)
+	}
+
+	// A higher rate threshold filters it out.
+	if leaks := d.Leaks(5.0, 0.6); len(leaks) != 0 {
+		t.Errorf("Leaks(5.0) returned %d leaks, want 0", len(leaks))
+	}
+}
+
+func TestLeakDetectorPIDReuse(t *testing.T) {
+	d := NewLeakDetector()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		d.Add([]Info{{PID: 9, Name: "old", RSS: int64(i) * 10 * 1024}}, start.Add(time.Duration(i)*time.Minute))
+	}
+	// PID 9 now belongs to another executable: history restarts.
+	d.Add([]Info{{PID: 9, Name: "new", RSS: 1024}}, start.Add(5*time.Minute))
+
+	if leaks := d.Leaks(0, 0); len(leaks) != 0 {
+		t.Errorf("Leaks() after PID reuse returned %+v, want none", leaks)
+	}
+}
+
+func TestLeakDetectorTooFewSamples(t *testing.T) {
+	d := NewLeakDetector()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.Add([]Info{{PID: 1, Name: "a", RSS: 1024}}, start)
+	d.Add([]Info{{PID: 1, Name: "a", RSS: 100 * 1024}}, start.Add(time.Minute))
+
+	if leaks := d.Leaks(0, 0); len(leaks) != 0 {
+		t.Errorf("Leaks() with 2 samples returned %d leaks, want 0", len(leaks))
+	}
+}
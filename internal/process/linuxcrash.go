@@ -0,0 +1,300 @@
+package process
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// linuxSignalNames maps Linux signal numbers to names. Apport and
+// systemd-coredump record signals numerically, using Linux numbering even
+// when the report is read on another platform.
+var linuxSignalNames = map[int]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	5:  "SIGTRAP",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	10: "SIGUSR1",
+	11: "SIGSEGV",
+	12: "SIGUSR2",
+	13: "SIGPIPE",
+	14: "SIGALRM",
+	15: "SIGTERM",
+	24: "SIGXCPU",
+	25: "SIGXFSZ",
+	31: "SIGSYS",
+}
+
+// linuxSignalName returns the name of a Linux signal number.
+func linuxSignalName(n int) string {
+	if name, ok := linuxSignalNames[n]; ok {
+		return name
+	}
+	return fmt.Sprintf("signal %d", n)
+}
+
+// parseApportFields reads an Apport .crash file into its key/value fields.
+// Multi-line values (continuation lines start with a space) are joined with
+// newlines. The base64 CoreDump field is skipped.
+func parseApportFields(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	fields := make(map[string]string)
+	var key string
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, " ") {
+			if key != "" && key != "CoreDump" {
+				if fields[key] != "" {
+					fields[key] += "\n"
+				}
+				fields[key] += strings.TrimPrefix(line, " ")
+			}
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			key = ""
+			continue
+		}
+		key = k
+		if key != "CoreDump" {
+			fields[key] = strings.TrimSpace(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if fields["ProblemType"] == "" {
+		return nil, fmt.Errorf("not an apport report: %s", path)
+	}
+	return fields, nil
+}
+
+// apportReport maps Apport fields to a CrashReport.
+func apportReport(path string, fields map[string]string) CrashReport {
+	report := CrashReport{
+		Timestamp:  fields["Date"],
+		ExceptType: fields["ProblemType"],
+		Path:       path,
+		ReportType: "apport",
+	}
+
+	if exe := fields["ExecutablePath"]; exe != "" {
+		report.Process = filepath.Base(exe)
+	}
+
+	// The PID is only recorded inside the ProcStatus block.
+	for _, line := range strings.Split(fields["ProcStatus"], "\n") {
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			switch strings.TrimSpace(k) {
+			case "Pid":
+				if pid, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+					report.PID = pid
+				}
+			case "Name":
+				if report.Process == "" {
+					report.Process = strings.TrimSpace(v)
+				}
+			}
+		}
+	}
+
+	switch {
+	case fields["SignalName"] != "":
+		report.Signal = fields["SignalName"]
+	case fields["Signal"] != "":
+		if n, err := strconv.Atoi(fields["Signal"]); err == nil {
+			report.Signal = linuxSignalName(n)
+		}
+	}
+	return report
+}
+
+// parseApportSummary extracts summary info from an Apport .crash file.
+func parseApportSummary(path string) (CrashReport, error) {
+	fields, err := parseApportFields(path)
+	if err != nil {
+		return CrashReport{}, err
+	}
+	return apportReport(path, fields), nil
+}
+
+// parseApportDetail parses an Apport .crash file in full detail.
+func parseApportDetail(path string) (*CrashDetail, error) {
+	fields, err := parseApportFields(path)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &CrashDetail{
+		CrashReport: apportReport(path, fields),
+		OSVersion:   fields["DistroRelease"],
+	}
+	// Package is "<name> <version>".
+	if pkg := strings.Fields(fields["Package"]); len(pkg) > 1 {
+		detail.Version = pkg[1]
+	}
+
+	stack := fields["StacktraceTop"]
+	if stack == "" {
+		stack = fields["Stacktrace"]
+	}
+	for _, line := range strings.Split(stack, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			detail.Backtrace = append(detail.Backtrace, line)
+		}
+	}
+	return detail, nil
+}
+
+// coredumpExts are the compression suffixes systemd-coredump may append.
+var coredumpExts = map[string]bool{".zst": true, ".lz4": true, ".xz": true}
+
+// parseCoredumpName parses a systemd-coredump file name of the form
+// core.<comm>.<uid>.<boot-id>.<pid>.<usec>[.zst|.lz4|.xz].
+func parseCoredumpName(name string) (CrashReport, bool) {
+	if !strings.HasPrefix(name, "core.") {
+		return CrashReport{}, false
+	}
+	if ext := filepath.Ext(name); coredumpExts[ext] {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(name, "core."), ".")
+	if len(parts) < 5 {
+		return CrashReport{}, false
+	}
+	n := len(parts)
+	usec, err := strconv.ParseInt(parts[n-1], 10, 64)
+	if err != nil {
+		return CrashReport{}, false
+	}
+	pid, err := strconv.Atoi(parts[n-2])
+	if err != nil {
+		return CrashReport{}, false
+	}
+	if len(parts[n-3]) != 32 {
+		return CrashReport{}, false
+	}
+	if _, err := strconv.Atoi(parts[n-4]); err != nil {
+		return CrashReport{}, false
+	}
+
+	// The command name itself may contain dots.
+	comm := strings.Join(parts[:n-4], ".")
+	return CrashReport{
+		Timestamp:  time.UnixMicro(usec).Format("2006-01-02 15:04:05 -0700"),
+		Process:    comm,
+		PID:        pid,
+		ReportType: "coredump",
+	}, true
+}
+
+// coredumpEntry is one entry of `coredumpctl --json=short list`.
+type coredumpEntry struct {
+	Time int64  `json:"time"` // microseconds since the epoch
+	PID  int    `json:"pid"`
+	Sig  int    `json:"sig"`
+	Exe  string `json:"exe"`
+}
+
+// parseCoredumpList parses the output of `coredumpctl --json=short list`.
+func parseCoredumpList(data []byte) ([]coredumpEntry, error) {
+	var entries []coredumpEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse coredumpctl output: %w", err)
+	}
+	return entries, nil
+}
+
+// coredumpTimeSlack is how far the journal time of a coredump may be from
+// the time in its core file name, which is when the process crashed: the
+// journal entry is only written once the core has been processed.
+const coredumpTimeSlack = 5 * time.Minute
+
+// coredumpIndex holds systemd-coredump journal metadata by PID. Since PIDs
+// are reused, a PID may have several entries, told apart by their time.
+type coredumpIndex map[int][]coredumpEntry
+
+// coredumpMetadata returns the systemd-coredump journal metadata, or nil if
+// coredumpctl is unavailable.
+func coredumpMetadata() coredumpIndex {
+	out, err := exec.Command("coredumpctl", "--json=short", "--no-pager", "list").Output()
+	if err != nil {
+		return nil
+	}
+	entries, err := parseCoredumpList(out)
+	if err != nil {
+		return nil
+	}
+	meta := make(coredumpIndex, len(entries))
+	for _, e := range entries {
+		meta[e.PID] = append(meta[e.PID], e)
+	}
+	return meta
+}
+
+// match returns the journal entry of the crash in report: the entry for its
+// PID closest in time, if within coredumpTimeSlack.
+func (idx coredumpIndex) match(report CrashReport) (coredumpEntry, bool) {
+	t, err := parseCrashTimestamp(report.Timestamp)
+	if err != nil {
+		return coredumpEntry{}, false
+	}
+	var best coredumpEntry
+	bestDiff := coredumpTimeSlack + 1
+	for _, e := range idx[report.PID] {
+		diff := time.UnixMicro(e.Time).Sub(t).Abs()
+		if diff < bestDiff {
+			best, bestDiff = e, diff
+		}
+	}
+	return best, bestDiff <= coredumpTimeSlack
+}
+
+// parseCoredumpSummary extracts summary info from a systemd-coredump file,
+// taking the signal from the journal metadata when it is available.
+func parseCoredumpSummary(path string, meta coredumpIndex) (CrashReport, error) {
+	report, ok := parseCoredumpName(filepath.Base(path))
+	if !ok {
+		return CrashReport{}, fmt.Errorf("not a systemd-coredump file: %s", path)
+	}
+	report.Path = path
+	if e, ok := meta.match(report); ok && e.Sig > 0 {
+		report.Signal = linuxSignalName(e.Sig)
+	}
+	return report, nil
+}
+
+// parseCoredumpDetail returns the details available for a systemd-coredump
+// file. The core itself is not parsed.
+func parseCoredumpDetail(path string) (*CrashDetail, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	report, err := parseCoredumpSummary(path, coredumpMetadata())
+	if err != nil {
+		return nil, err
+	}
+	return &CrashDetail{CrashReport: report}, nil
+}
@@ -0,0 +1,247 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const apportSample = `ProblemType: Crash
+Architecture: amd64
+CoreDump: base64
+ H4sICAAAAAAC/0NvcmVEdW1wAA==
+ 7b0LYBxXeS+eNr20
+Date: Tue Feb 10 14:30:00 2026
+DistroRelease: Ubuntu 24.04
+ExecutablePath: /usr/bin/myapp
+Package: myapp 1.2.3-1ubuntu1
+ProcCmdline: /usr/bin/myapp --serve
+ProcStatus:
+ Name:	myapp
+ Umask:	0022
+ State:	S (sleeping)
+ Tgid:	4321
+ Pid:	4321
+Signal: 11
+StacktraceTop:
+ handle_request (req=0x0) at server.c:42
+ main (argc=2, argv=0x7ffd) at main.c:10
+`
+
+func TestParseApportSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "_usr_bin_myapp.1000.crash")
+	if err := os.WriteFile(path, []byte(apportSample), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	report, err := parseApportSummary(path)
+	if err != nil {
+		t.Fatalf("parseApportSummary() error: %v", err)
+	}
+
+	if report.Process != "myapp" {
+		t.Errorf("Process = %q, want myapp", report.Process)
+	}
+	if report.PID != 4321 {
+		t.Errorf("PID = %d, want 4321", report.PID)
+	}
+	if report.Signal != "SIGSEGV" {
+		t.Errorf("Signal = %q, want SIGSEGV", report.Signal)
+	}
+	if report.Timestamp != "Tue Feb 10 14:30:00 2026" {
+		t.Errorf("Timestamp = %q, want %q", report.Timestamp, "Tue Feb 10 14:30:00 2026")
+	}
+	if report.ReportType != "apport" {
+		t.Errorf("ReportType = %q, want apport", report.ReportType)
+	}
+	if report.ExceptType != "Crash" {
+		t.Errorf("ExceptType = %q, want Crash", report.ExceptType)
+	}
+	if _, err := parseCrashTimestamp(report.Timestamp); err != nil {
+		t.Errorf("parseCrashTimestamp(%q) error: %v", report.Timestamp, err)
+	}
+}
+
+func TestParseApportDetail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "_usr_bin_myapp.1000.crash")
+	if err := os.WriteFile(path, []byte(apportSample), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	detail, err := GetCrashDetail(path)
+	if err != nil {
+		t.Fatalf("GetCrashDetail() error: %v", err)
+	}
+	if detail.OSVersion != "Ubuntu 24.04" {
+		t.Errorf("OSVersion = %q, want Ubuntu 24.04", detail.OSVersion)
+	}
+	if detail.Version != "1.2.3-1ubuntu1" {
+		t.Errorf("Version = %q, want 1.2.3-1ubuntu1", detail.Version)
+	}
+	if len(detail.Backtrace) != 2 || detail.Backtrace[0] != "handle_request (req=0x0) at server.c:42" {
+		t.Errorf("Backtrace = %q, want two StacktraceTop frames", detail.Backtrace)
+	}
+}
+
+func TestParseApportSummary_NotApport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.crash")
+	if err := os.WriteFile(path, []byte("Process: Foo [1]\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if _, err := parseApportSummary(path); err == nil {
+		t.Error("parseApportSummary() should reject files without ProblemType")
+	}
+}
+
+func TestParseApportSummary_SignalName(t *testing.T) {
+	content := "ProblemType: Crash\nExecutablePath: /opt/x/worker\nSignal: 6\nSignalName: SIGABRT\n"
+	path := filepath.Join(t.TempDir(), "w.crash")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	report, err := parseApportSummary(path)
+	if err != nil {
+		t.Fatalf("parseApportSummary() error: %v", err)
+	}
+	if report.Signal != "SIGABRT" || report.Process != "worker" {
+		t.Errorf("report = %+v, want worker SIGABRT", report)
+	}
+}
+
+func TestParseCoredumpName(t *testing.T) {
+	usec := time.Date(2026, 2, 10, 14, 30, 0, 0, time.UTC).UnixMicro()
+	bootID := "0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name     string
+		file     string
+		wantOK   bool
+		wantProc string
+		wantPID  int
+	}{
+		{"zstd", "core.myapp.1000." + bootID + ".4321." + itoa64(usec) + ".zst", true, "myapp", 4321},
+		{"uncompressed", "core.myapp.1000." + bootID + ".4321." + itoa64(usec), true, "myapp", 4321},
+		{"dotted comm", "core.my.app.0." + bootID + ".7." + itoa64(usec) + ".lz4", true, "my.app", 7},
+		{"bare core", "core", false, "", 0},
+		{"bad boot id", "core.myapp.1000.xyz.4321." + itoa64(usec), false, "", 0},
+		{"other file", "notes.txt", false, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseCoredumpName(tt.file)
+			if ok != tt.wantOK {
+				t.Fatalf("parseCoredumpName(%q) ok = %v, want %v", tt.file, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Process != tt.wantProc || got.PID != tt.wantPID {
+				t.Errorf("got %s [%d], want %s [%d]", got.Process, got.PID, tt.wantProc, tt.wantPID)
+			}
+			ts, err := parseCrashTimestamp(got.Timestamp)
+			if err != nil {
+				t.Fatalf("parseCrashTimestamp(%q) error: %v", got.Timestamp, err)
+			}
+			if !ts.Equal(time.UnixMicro(usec)) {
+				t.Errorf("timestamp = %v, want %v", ts, time.UnixMicro(usec))
+			}
+		})
+	}
+}
+
+func TestParseCoredumpList(t *testing.T) {
+	data := []byte(`[{"time":1770733800000000,"pid":4321,"uid":1000,"gid":1000,"sig":6,"corefile":"present","exe":"/usr/bin/myapp","size":12345}]`)
+	entries, err := parseCoredumpList(data)
+	if err != nil {
+		t.Fatalf("parseCoredumpList() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].PID != 4321 || entries[0].Sig != 6 {
+		t.Errorf("entries = %+v, want one entry for PID 4321 with signal 6", entries)
+	}
+
+	// The journal entry is written a few seconds after the crash, and an
+	// earlier process with the same PID crashed with another signal.
+	entry := entries[0]
+	entry.Time += 3_000_000
+	earlier := coredumpEntry{Time: entry.Time - 24*3600_000_000, PID: 4321, Sig: 11}
+	meta := coredumpIndex{4321: {earlier, entry}}
+	const core = "/var/lib/systemd/coredump/core.myapp.1000.0123456789abcdef0123456789abcdef.4321.1770733800000000.zst"
+	report, err := parseCoredumpSummary(core, meta)
+	if err != nil {
+		t.Fatalf("parseCoredumpSummary() error: %v", err)
+	}
+	if report.Signal != "SIGABRT" {
+		t.Errorf("Signal = %q, want SIGABRT from the entry matching the core's time", report.Signal)
+	}
+
+	// An entry for the same PID at another time is a different crash.
+	report, err = parseCoredumpSummary(core, coredumpIndex{4321: {earlier}})
+	if err != nil {
+		t.Fatalf("parseCoredumpSummary() error: %v", err)
+	}
+	if report.Signal != "" {
+		t.Errorf("Signal = %q, want none from an unrelated crash of a reused PID", report.Signal)
+	}
+
+	if _, err := parseCoredumpList([]byte("not json")); err == nil {
+		t.Error("parseCoredumpList(invalid) should return error")
+	}
+}
+
+func TestListCrashReportsIn(t *testing.T) {
+	macDir := t.TempDir()
+	linuxDir := t.TempDir()
+
+	now := time.Now()
+	header := `{"app_name":"MacApp","timestamp":"` + now.Format("2006-01-02 15:04:05") + `","name":"MacApp"}`
+	body := `{"pid": 100, "exception": {"type": "EXC_CRASH", "signal": "SIGABRT"}}`
+	if err := os.WriteFile(filepath.Join(macDir, "MacApp.ips"), []byte(header+"\n"+body), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	apport := "ProblemType: Crash\nDate: " + now.Format(time.ANSIC) + "\nExecutablePath: /usr/bin/linuxapp\nSignal: 11\n"
+	if err := os.WriteFile(filepath.Join(linuxDir, "_usr_bin_linuxapp.0.crash"), []byte(apport), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	core := "core.daemon.0.0123456789abcdef0123456789abcdef.77." + itoa64(now.UnixMicro()) + ".zst"
+	if err := os.WriteFile(filepath.Join(linuxDir, core), nil, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(linuxDir, "README"), []byte("ignored"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	reports, err := ListCrashReportsIn([]string{macDir, linuxDir, "/nonexistent/dir"}, "1d", "")
+	if err != nil {
+		t.Fatalf("ListCrashReportsIn() error: %v", err)
+	}
+	if len(reports) != 3 {
+		t.Fatalf("ListCrashReportsIn() returned %d reports, want 3: %+v", len(reports), reports)
+	}
+
+	types := make(map[string]string)
+	for _, r := range reports {
+		types[r.Process] = r.ReportType
+	}
+	want := map[string]string{"MacApp": "crash", "linuxapp": "apport", "daemon": "coredump"}
+	for proc, typ := range want {
+		if types[proc] != typ {
+			t.Errorf("report for %s has type %q, want %q", proc, types[proc], typ)
+		}
+	}
+
+	filtered, err := ListCrashReportsIn([]string{macDir, linuxDir}, "1d", "linuxapp")
+	if err != nil {
+		t.Fatalf("ListCrashReportsIn() error: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Process != "linuxapp" {
+		t.Errorf("filtered = %+v, want only linuxapp", filtered)
+	}
+}
+
+func itoa64(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
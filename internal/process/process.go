@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Info holds basic process information.
@@ -15,9 +16,20 @@ type Info struct {
 	Name    string  `json:"name"`
 	CPU     float64 `json:"cpu"`
 	Mem     float64 `json:"mem"`
+	RSS     int64   `json:"rss_kb"` // resident set size in KB
 	User    string  `json:"user"`
 	State   string  `json:"state"`
 	Command string  `json:"command"`
+
+	// Filled in only on request by AddPSDetails, AddThreadCounts,
+	// AddListeningPorts, and AddOpenFileCounts.
+	VSZ       int64     `json:"vsz_kb,omitempty"` // virtual size in KB
+	Nice      int       `json:"nice,omitempty"`
+	Started   time.Time `json:"started,omitzero"`
+	TTY       string    `json:"tty,omitempty"`
+	Threads   int       `json:"threads,omitempty"`
+	Ports     []int     `json:"ports,omitempty"`
+	OpenFiles int       `json:"open_files,omitempty"`
 }
 
 // List returns all running processes.
@@ -62,6 +74,63 @@ func Find(query string) ([]Info, error) {
 	return result, nil
 }
 
+// StartTime returns the time at which a process was started.
+func StartTime(pid int) (time.Time, error) {
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get start time of PID %d: %w", pid, err)
+	}
+	return parseLstart(string(out))
+}
+
+// AddStartTimes fills in the exact start time of procs, as returned by
+// StartTime, so that a process can later be told apart from one that
+// reused its PID. It overrides the approximate time set by AddPSDetails.
+func AddStartTimes(procs []Info) error {
+	out, err := exec.Command("ps", "-eo", "pid=,lstart=").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get start times: %w", err)
+	}
+	started := parseStartTimes(string(out))
+	for i := range procs {
+		if t, ok := started[procs[i].PID]; ok {
+			procs[i].Started = t
+		}
+	}
+	return nil
+}
+
+// parseStartTimes parses the output of `ps -eo pid=,lstart=`.
+func parseStartTimes(out string) map[int]time.Time {
+	started := make(map[int]time.Time)
+	for _, line := range strings.Split(out, "\n") {
+		pidStr, rest, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil {
+			continue
+		}
+		t, err := parseLstart(rest)
+		if err != nil {
+			continue
+		}
+		started[pid] = t
+	}
+	return started
+}
+
+// parseLstart parses the lstart column of ps, e.g. "Sat Oct  4 10:12:01 2026".
+func parseLstart(s string) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	t, err := time.ParseInLocation("Mon Jan 2 15:04:05 2006", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse start time %q: %w", s, err)
+	}
+	return t, nil
+}
+
 // ParsePSOutput parses the output of `ps -eo pid,ppid,user,stat,%cpu,rss,comm`.
 func ParsePSOutput(output string) ([]Info, error) {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -130,6 +199,7 @@ func parsePSLine(line string) (Info, error) {
 		Name:    name,
 		CPU:     cpu,
 		Mem:     mem,
+		RSS:     int64(rss),
 		User:    user,
 		State:   state,
 		Command: command,
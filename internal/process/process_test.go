@@ -1,8 +1,10 @@
 package process
 
 import (
+	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParsePSOutput(t *testing.T) {
@@ -232,3 +234,57 @@ func TestFindNoResults(t *testing.T) {
 		t.Errorf("Find(nonexistent) returned %d results, want 0", len(procs))
 	}
 }
+
+func TestParseLstart(t *testing.T) {
+	want := time.Date(2026, 10, 4, 10, 12, 1, 0, time.Local)
+	for _, in := range []string{"Sun Oct  4 10:12:01 2026\n", "Sun Oct 4 10:12:01 2026"} {
+		got, err := parseLstart(in)
+		if err != nil {
+			t.Fatalf("parseLstart(%q) error: %v", in, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseLstart(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := parseLstart("garbage"); err == nil {
+		t.Error("parseLstart(garbage) should return error")
+	}
+}
+
+func TestParseStartTimes(t *testing.T) {
+	out := `    1 Sun Oct  4 10:12:01 2026
+  501 Mon Oct  5 08:00:00 2026
+bogus line
+`
+	started := parseStartTimes(out)
+	if len(started) != 2 {
+		t.Fatalf("parseStartTimes() returned %d entries, want 2", len(started))
+	}
+	if want := time.Date(2026, 10, 4, 10, 12, 1, 0, time.Local); !started[1].Equal(want) {
+		t.Errorf("PID 1 started %v, want %v", started[1], want)
+	}
+}
+
+func TestAddStartTimes(t *testing.T) {
+	procs := []Info{{PID: os.Getpid()}}
+	if err := AddStartTimes(procs); err != nil {
+		t.Fatalf("AddStartTimes() error: %v", err)
+	}
+	want, err := StartTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("StartTime(self) error: %v", err)
+	}
+	if !procs[0].Started.Equal(want) {
+		t.Errorf("Started = %v, want %v as returned by StartTime", procs[0].Started, want)
+	}
+}
+
+func TestStartTime(t *testing.T) {
+	started, err := StartTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("StartTime(self) error: %v", err)
+	}
+	if started.After(time.Now()) || time.Since(started) > 24*time.Hour {
+		t.Errorf("StartTime(self) = %v, want a recent time", started)
+	}
+}
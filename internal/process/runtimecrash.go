@@ -0,0 +1,617 @@
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Crashes written by language runtimes rather than the OS: Go panics and
+// Python tracebacks found in log files, and JVM hs_err_pid<N>.log files.
+
+var (
+	goPanicRegex     = regexp.MustCompile(`^(panic|fatal error): (.+)$`)
+	goSignalRegex    = regexp.MustCompile(`^\[signal (SIG[A-Z]+)`)
+	goGoroutineRegex = regexp.MustCompile(`^goroutine (\d+) \[[^\]]*\]:$`)
+	goFileRegex      = regexp.MustCompile(`^\t(.+?:\d+)(?: \+0x[0-9a-f]+)?$`)
+
+	pyFrameRegex     = regexp.MustCompile(`^\s+File "(.+)", line (\d+), in (.+)$`)
+	pyExceptionRegex = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::\s*(.*))?$`)
+
+	jvmSignalRegex   = regexp.MustCompile(`^#\s+((?:SIG[A-Z]+|EXCEPTION_\w+)) \(0x[0-9a-fA-F]+\) at pc=\S+, pid=(\d+), tid=(\d+)`)
+	jvmInternalRegex = regexp.MustCompile(`^#\s+Internal Error \((.+)\)`)
+	jvmMemoryRegex   = regexp.MustCompile(`^#\s+((?:There is insufficient memory|Out of Memory Error).*)$`)
+	jvmVersionRegex  = regexp.MustCompile(`^# JRE version: (.+)$`)
+	jvmBuildRegex    = regexp.MustCompile(`\(build ([^)]+)\)`)
+	jvmTimeRegex     = regexp.MustCompile(`(?i)^time: (.+?)(?:\s+elapsed time:.*)?$`)
+	jvmFrameRegex    = regexp.MustCompile(`^([CjJAVv])\s+(.+)$`)
+	jvmPIDNameRegex  = regexp.MustCompile(`^hs_err_pid(\d+)\.log$`)
+
+	// logTimeRegex matches a timestamp at the start of a log line, such as
+	// "2026-02-10 14:30:00,123", "[2026-02-10T14:30:00Z]", or Go's
+	// "2026/02/10 14:30:00".
+	logTimeRegex = regexp.MustCompile(`^\[?(\d{4})[-/](\d{2})[-/](\d{2})[ T](\d{2}:\d{2}:\d{2})(?:[.,]\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+)
+
+const (
+	// maxLogLine is the longest log line kept; the rest of a longer line is
+	// skipped.
+	maxLogLine = 64 * 1024
+	// maxCrashLines bounds how many lines a crash in a log file may span.
+	maxCrashLines = 2000
+	// maxCachedLogs bounds the number of parsed log files kept in logCache.
+	maxCachedLogs = 256
+)
+
+const (
+	pyTracebackHeader = "Traceback (most recent call last):"
+	jvmFatalHeader    = "A fatal error has been detected by the Java Runtime Environment"
+	jvmMemoryHeader   = "insufficient memory for the Java Runtime Environment"
+)
+
+// isJVMCrashName reports whether name is a JVM fatal error log file name.
+func isJVMCrashName(name string) bool {
+	return strings.HasPrefix(name, "hs_err_pid") && strings.HasSuffix(name, ".log")
+}
+
+// CrashLogFiles expands log file glob patterns into a sorted list of files.
+// Invalid patterns and directories are skipped.
+func CrashLogFiles(globs []string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, pattern := range globs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		for _, m := range matches {
+			if seen[m] {
+				continue
+			}
+			if fi, err := os.Stat(m); err != nil || fi.IsDir() {
+				continue
+			}
+			seen[m] = true
+			files = append(files, m)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// lineReader reads a text file line by line. Lines longer than maxLogLine
+// are truncated, so that one of them does not end the scan.
+type lineReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReaderSize(r, maxLogLine)}
+}
+
+// next returns the next line, without its line ending. It returns false at
+// the end of the file or on a read error, which err then reports.
+func (lr *lineReader) next() (string, bool) {
+	if lr.err != nil {
+		return "", false
+	}
+	b, err := lr.r.ReadSlice('\n')
+	line := string(b)
+	for err == bufio.ErrBufferFull {
+		_, err = lr.r.ReadSlice('\n')
+	}
+	if err != nil {
+		lr.err = err
+		if line == "" {
+			return "", false
+		}
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), true
+}
+
+// Err returns the first read error, if any.
+func (lr *lineReader) Err() error {
+	if lr.err == io.EOF {
+		return nil
+	}
+	return lr.err
+}
+
+// readLines reads a text file into lines.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	lr := newLineReader(f)
+	for line, ok := lr.next(); ok; line, ok = lr.next() {
+		lines = append(lines, line)
+	}
+	if err := lr.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// cachedLog is the result of parsing a log file, valid while the file keeps
+// its size and modification time.
+type cachedLog struct {
+	size    int64
+	modTime time.Time
+	details []*CrashDetail
+}
+
+var (
+	logCacheMu sync.Mutex
+	logCache   = make(map[string]cachedLog)
+)
+
+// parseCrashLog returns every crash found in a log file, detecting the
+// format from its contents. A JVM fatal error log is a single crash; other
+// files may hold any number of Go panics and Python tracebacks, each
+// identified by the line it starts on. Files that have not changed since
+// they were last parsed are not parsed again.
+func parseCrashLog(path string) ([]*CrashDetail, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	logCacheMu.Lock()
+	c, ok := logCache[path]
+	logCacheMu.Unlock()
+	if !ok || c.size != fi.Size() || !c.modTime.Equal(fi.ModTime()) {
+		details, err := scanCrashLog(path, fi.ModTime())
+		if err != nil {
+			return nil, err
+		}
+		c = cachedLog{size: fi.Size(), modTime: fi.ModTime(), details: details}
+		logCacheMu.Lock()
+		if len(logCache) >= maxCachedLogs {
+			clear(logCache)
+		}
+		logCache[path] = c
+		logCacheMu.Unlock()
+	}
+
+	// Copies keep callers from changing the cached details.
+	details := make([]*CrashDetail, len(c.details))
+	for i, d := range c.details {
+		cp := *d
+		details[i] = &cp
+	}
+	return details, nil
+}
+
+// scanCrashLog parses a log file as described for parseCrashLog. Other
+// than JVM fatal error logs, which are small, the file is read as a stream,
+// keeping only the lines a crash may still span.
+func scanCrashLog(path string, modTime time.Time) ([]*CrashDetail, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	lr := newLineReader(f)
+	var lines []string
+	// fill reads lines until there are n or the file ends.
+	fill := func(n int) {
+		for len(lines) < n {
+			line, ok := lr.next()
+			if !ok {
+				return
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	fill(10)
+	if isJVMCrashLog(lines) {
+		for line, ok := lr.next(); ok; line, ok = lr.next() {
+			lines = append(lines, line)
+		}
+		if err := lr.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return []*CrashDetail{jvmDetail(path, lines)}, nil
+	}
+
+	var details []*CrashDetail
+	base := 0 // line number of lines[0], less one
+	for i := 0; ; i++ {
+		if i >= maxCrashLines {
+			// Drop the lines no crash can reach back to; logTimestamp
+			// looks at the 5 lines before a crash.
+			lines = append([]string(nil), lines[i-5:]...)
+			base += i - 5
+			i = 5
+		}
+		fill(i + maxCrashLines)
+		if i >= len(lines) {
+			break
+		}
+
+		var d *CrashDetail
+		next := i
+		switch {
+		case goPanicRegex.MatchString(lines[i]):
+			d, next = parseGoPanic(lines, i)
+		case strings.TrimSpace(lines[i]) == pyTracebackHeader:
+			d, next = parsePythonTraceback(lines, i)
+		}
+		if d == nil {
+			continue
+		}
+
+		d.Path = path
+		d.Line = base + i + 1
+		if d.Process == "" {
+			d.Process = logProcessName(path)
+		}
+		if raw, ts, ok := logTimestamp(lines, i); ok {
+			d.RawTimestamp = raw
+			d.Timestamp = ts.Format(time.RFC3339)
+		} else if !modTime.IsZero() {
+			d.Timestamp = modTime.Format(time.RFC3339)
+		}
+		details = append(details, d)
+		i = next - 1
+	}
+	if err := lr.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return details, nil
+}
+
+// logProcessName derives a process name from a log file name, e.g.
+// "/var/log/api-server.log" gives "api-server".
+func logProcessName(path string) string {
+	name := filepath.Base(path)
+	for ext := filepath.Ext(name); ext != "" && ext != name; ext = filepath.Ext(name) {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// logTimestamp looks for a log line timestamp on the line a crash starts on
+// or on one of the few lines before it.
+func logTimestamp(lines []string, start int) (string, time.Time, bool) {
+	for i := start; i >= 0 && i >= start-5; i-- {
+		m := logTimeRegex.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		s := fmt.Sprintf("%s-%s-%s %s", m[1], m[2], m[3], m[4])
+		var t time.Time
+		var err error
+		switch zone := strings.Replace(m[5], ":", "", 1); zone {
+		case "":
+			t, err = time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+		case "Z":
+			t, err = time.ParseInLocation("2006-01-02 15:04:05", s, time.UTC)
+		default:
+			t, err = time.Parse("2006-01-02 15:04:05 -0700", s+" "+zone)
+		}
+		if err == nil {
+			return strings.Trim(m[0], "["), t, true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// parseGoPanic parses a Go panic or fatal error starting at lines[start],
+// keeping the backtrace of the goroutine that panicked. It returns the
+// index of the first line after the backtrace, or nil if no goroutine
+// backtrace follows the message.
+func parseGoPanic(lines []string, start int) (*CrashDetail, int) {
+	m := goPanicRegex.FindStringSubmatch(lines[start])
+	d := &CrashDetail{
+		CrashReport: CrashReport{ExceptType: m[1], ReportType: "go"},
+		Message:     strings.TrimSpace(m[2]),
+	}
+
+	i := start + 1
+	goroutine := -1
+	// The goroutine header must follow shortly, after any "[recovered]"
+	// panics and the signal line.
+	for ; i < len(lines) && i <= start+20; i++ {
+		if s := goSignalRegex.FindStringSubmatch(lines[i]); s != nil {
+			d.Signal = s[1]
+		}
+		if g := goGoroutineRegex.FindStringSubmatch(lines[i]); g != nil {
+			goroutine, _ = strconv.Atoi(g[1])
+			i++
+			break
+		}
+	}
+	if goroutine < 0 {
+		return nil, start + 1
+	}
+	d.CrashThread = goroutine
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "created by ") {
+			break
+		}
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+		// Drop the argument list; arguments never contain parentheses but
+		// receiver types in the function name may.
+		frame := line
+		if p := strings.LastIndex(line, "("); p > 0 && strings.HasSuffix(line, ")") {
+			frame = line[:p]
+		}
+		if i+1 < len(lines) {
+			if f := goFileRegex.FindStringSubmatch(lines[i+1]); f != nil {
+				frame += " (" + filepath.Base(f[1]) + ")"
+				i++
+			}
+		}
+		d.Backtrace = append(d.Backtrace, frame)
+	}
+	return d, i
+}
+
+// parsePythonTraceback parses a Python traceback starting at lines[start].
+// Chained exceptions are followed to the last one, which is reported. It
+// returns the index of the first line after the traceback.
+func parsePythonTraceback(lines []string, start int) (*CrashDetail, int) {
+	d := &CrashDetail{CrashReport: CrashReport{ReportType: "python"}}
+	i := start
+	for {
+		var frames []string
+		var script string
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if line == "" || (line[0] != ' ' && line[0] != '\t') {
+				break
+			}
+			if f := pyFrameRegex.FindStringSubmatch(line); f != nil {
+				if script == "" {
+					script = f[1]
+				}
+				frames = append(frames, fmt.Sprintf("%s (%s:%s)", f[3], filepath.Base(f[1]), f[2]))
+			}
+		}
+
+		// Frames are listed outermost first.
+		d.Backtrace = d.Backtrace[:0]
+		for j := len(frames) - 1; j >= 0; j-- {
+			d.Backtrace = append(d.Backtrace, frames[j])
+		}
+		if strings.HasSuffix(script, ".py") {
+			d.Process = filepath.Base(script)
+		}
+		if i < len(lines) {
+			if e := pyExceptionRegex.FindStringSubmatch(lines[i]); e != nil {
+				d.ExceptType = e[1]
+				d.Message = strings.TrimSpace(e[2])
+				i++
+			}
+		}
+
+		// A chained exception is introduced by a sentence, a blank line,
+		// and a new traceback.
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+			j++
+		}
+		if j >= len(lines) || !strings.HasPrefix(lines[j], "During handling of the above exception") &&
+			!strings.HasPrefix(lines[j], "The above exception was the direct cause") {
+			break
+		}
+		for j++; j < len(lines) && strings.TrimSpace(lines[j]) == ""; j++ {
+		}
+		if j >= len(lines) || strings.TrimSpace(lines[j]) != pyTracebackHeader {
+			break
+		}
+		i = j
+	}
+	return d, i
+}
+
+// isJVMCrashLog reports whether lines are a JVM fatal error log.
+func isJVMCrashLog(lines []string) bool {
+	for i, line := range lines {
+		if i >= 10 {
+			break
+		}
+		if strings.Contains(line, jvmFatalHeader) || strings.Contains(line, jvmMemoryHeader) {
+			return true
+		}
+	}
+	return false
+}
+
+// jvmDetail parses a JVM hs_err_pid<N>.log file.
+func jvmDetail(path string, lines []string) *CrashDetail {
+	d := &CrashDetail{CrashReport: CrashReport{Path: path, ReportType: "jvm"}}
+	if m := jvmPIDNameRegex.FindStringSubmatch(filepath.Base(path)); m != nil {
+		d.PID, _ = strconv.Atoi(m[1])
+	}
+
+	var nativeFrames, javaFrames []string
+	var section *[]string
+	for i, line := range lines {
+		if section != nil {
+			if m := jvmFrameRegex.FindStringSubmatch(line); m != nil {
+				*section = append(*section, m[1]+" "+strings.Join(strings.Fields(m[2]), " "))
+				continue
+			}
+			section = nil
+		}
+
+		switch {
+		case strings.HasPrefix(line, "Native frames:"):
+			section = &nativeFrames
+		case strings.HasPrefix(line, "Java frames:"):
+			section = &javaFrames
+		case strings.HasPrefix(line, "# Problematic frame:") && i+1 < len(lines):
+			d.Message = strings.TrimSpace(strings.TrimPrefix(lines[i+1], "#"))
+		case strings.HasPrefix(line, "Command Line:"):
+			d.Process = javaMainName(strings.TrimSpace(strings.TrimPrefix(line, "Command Line:")))
+		}
+
+		if m := jvmSignalRegex.FindStringSubmatch(line); m != nil {
+			d.ExceptType = "fatal error"
+			d.Signal = m[1]
+			d.PID, _ = strconv.Atoi(m[2])
+		}
+		if m := jvmInternalRegex.FindStringSubmatch(line); m != nil {
+			d.ExceptType = "internal error"
+			d.Message = m[1]
+		}
+		if m := jvmMemoryRegex.FindStringSubmatch(line); m != nil && d.ExceptType == "" {
+			d.ExceptType = "out of memory"
+			d.Message = strings.TrimSpace(m[1])
+		}
+		if m := jvmVersionRegex.FindStringSubmatch(line); m != nil {
+			d.Version = m[1]
+			if b := jvmBuildRegex.FindStringSubmatch(m[1]); b != nil {
+				d.Version = b[1]
+			}
+		}
+		if m := jvmTimeRegex.FindStringSubmatch(line); m != nil && d.Timestamp == "" {
+			d.RawTimestamp = m[1]
+			for _, layout := range []string{"Mon Jan _2 15:04:05 2006 MST", time.ANSIC} {
+				if t, err := time.ParseInLocation(layout, m[1], time.Local); err == nil {
+					d.Timestamp = t.Format(time.RFC3339)
+					break
+				}
+			}
+		}
+	}
+
+	d.Backtrace = nativeFrames
+	if len(d.Backtrace) == 0 {
+		d.Backtrace = javaFrames
+	}
+	if d.Process == "" {
+		d.Process = "java"
+	}
+	if d.Timestamp == "" {
+		if fi, err := os.Stat(path); err == nil {
+			d.Timestamp = fi.ModTime().Format(time.RFC3339)
+		}
+	}
+	return d
+}
+
+// javaMainName returns the main class or jar file name from the arguments
+// of a java command line.
+func javaMainName(args string) string {
+	fields := strings.Fields(args)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch {
+		case f == "-jar" && i+1 < len(fields):
+			return filepath.Base(fields[i+1])
+		case f == "-cp" || f == "-classpath" || f == "--class-path" || f == "-p" || f == "--module-path":
+			i++
+		case strings.HasPrefix(f, "-"):
+		default:
+			return f
+		}
+	}
+	return ""
+}
+
+// parseJVMSummary extracts summary info from a JVM hs_err_pid<N>.log file.
+func parseJVMSummary(path string) (CrashReport, error) {
+	d, err := parseJVMDetail(path)
+	if err != nil {
+		return CrashReport{}, err
+	}
+	return d.CrashReport, nil
+}
+
+// parseJVMDetail parses a JVM hs_err_pid<N>.log file in full detail.
+func parseJVMDetail(path string) (*CrashDetail, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isJVMCrashLog(lines) {
+		return nil, fmt.Errorf("%s is not a JVM fatal error log", path)
+	}
+	return jvmDetail(path, lines), nil
+}
+
+// scanCrashLogs returns the crashes found in the log files matching globs.
+func scanCrashLogs(globs []string) []CrashReport {
+	var reports []CrashReport
+	for _, path := range CrashLogFiles(globs) {
+		details, err := parseCrashLog(path)
+		if err != nil {
+			continue
+		}
+		for _, d := range details {
+			reports = append(reports, d.CrashReport)
+		}
+	}
+	return reports
+}
+
+// parseLogLocation splits a "path:line" reference to a crash in a log file.
+// The line is 0 if s has no line suffix.
+func parseLogLocation(s string) (string, int) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return s, 0
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil || line <= 0 {
+		return s, 0
+	}
+	if _, err := os.Stat(s); err == nil {
+		// A file whose name really ends in ":<digits>".
+		return s, 0
+	}
+	return s[:i], line
+}
+
+// crashLogDetail returns the crash starting at line of a log file, or the
+// last crash in the file if line is 0.
+func crashLogDetail(path string, line int) (*CrashDetail, error) {
+	details, err := parseCrashLog(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, fmt.Errorf("unsupported report format: %s (no Go, JVM, or Python crash found)", filepath.Base(path))
+	}
+	if line == 0 {
+		return details[len(details)-1], nil
+	}
+	for _, d := range details {
+		if d.Line == line {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("no crash starts at line %d of %s", line, path)
+}
+
+// Location returns where a report can be found: its path, followed by the
+// line number for crashes found in log files.
+func (r CrashReport) Location() string {
+	if r.Line > 0 {
+		return fmt.Sprintf("%s:%d", r.Path, r.Line)
+	}
+	return r.Path
+}
@@ -0,0 +1,324 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleGoLog = `2026/02/10 14:29:58 listening on :8080
+2026/02/10 14:30:00 handling request /users/42
+panic: runtime error: invalid memory address or nil pointer dereference
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a1b2c]
+
+goroutine 34 [running]:
+main.(*Server).handle(0x0, {0x6f2a40, 0xc0000a6000})
+	/src/api/server.go:42 +0x2c
+net/http.HandlerFunc.ServeHTTP(...)
+	/usr/local/go/src/net/http/server.go:2136
+main.main()
+	/src/api/main.go:17 +0x85
+created by net/http.(*Server).Serve in goroutine 1
+	/usr/local/go/src/net/http/server.go:3285 +0x4b4
+
+goroutine 1 [IO wait]:
+internal/poll.runtime_pollWait(0x7f, 0x72)
+	/usr/local/go/src/runtime/netpoll.go:343 +0x85
+exit status 2
+2026/02/10 15:00:00 listening on :8080
+fatal error: concurrent map writes
+
+goroutine 7 [running]:
+main.record(...)
+	/src/api/stats.go:9
+`
+
+const samplePythonLog = `2026-02-10 09:15:00,120 INFO worker started
+2026-02-10 09:15:03,481 ERROR job failed
+Traceback (most recent call last):
+  File "/srv/jobs/worker.py", line 88, in run
+    result = process(job)
+  File "/srv/jobs/worker.py", line 41, in process
+    return parse(job.payload)
+KeyError: 'payload'
+
+During handling of the above exception, another exception occurred:
+
+Traceback (most recent call last):
+  File "/srv/jobs/worker.py", line 90, in run
+    raise JobError(job.id) from None
+  File "/srv/jobs/errors.py", line 12, in __init__
+    super().__init__(f"job {id} failed")
+jobs.errors.JobError: job 17 failed
+2026-02-10 09:15:04,002 INFO retrying
+`
+
+const sampleHsErr = `#
+# A fatal error has been detected by the Java Runtime Environment:
+#
+#  SIGSEGV (0xb) at pc=0x00007f3a2c18b7a5, pid=4321, tid=4322
+#
+# JRE version: OpenJDK Runtime Environment (17.0.2+8) (build 17.0.2+8-86)
+# Java VM: OpenJDK 64-Bit Server VM (17.0.2+8-86, mixed mode, sharing, tiered, linux-amd64)
+# Problematic frame:
+# C  [libc.so.6+0x18b7a5]  __memmove_avx_unaligned_erms+0x25
+#
+
+---------------  S U M M A R Y ------------
+
+Command Line: -Xmx2g -cp app.jar com.example.ingest.Main --port 9000
+
+Time: Tue Feb 10 14:30:00 2026 UTC elapsed time: 12.345 seconds (0d 0h 0m 12s)
+
+---------------  T H R E A D  ---------------
+
+Current thread (0x00007f3a2402a000):  JavaThread "main" [_thread_in_native, id=4322]
+
+Native frames: (J=compiled Java code, j=interpreted, Vv=VM code, C=native code)
+C  [libc.so.6+0x18b7a5]  __memmove_avx_unaligned_erms+0x25
+j  com.example.ingest.Buffer.copy([BI)V+12
+j  com.example.ingest.Main.main([Ljava/lang/String;)V+40
+v  ~StubRoutines::call_stub
+
+Java frames: (J=compiled Java code, j=interpreted, Vv=VM code)
+j  com.example.ingest.Buffer.copy([BI)V+12
+`
+
+func writeLog(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestParseCrashLog_Go(t *testing.T) {
+	path := writeLog(t, t.TempDir(), "api-server.log", sampleGoLog)
+
+	details, err := parseCrashLog(path)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(details) != 2 {
+		t.Fatalf("parseCrashLog() returned %d crashes, want 2", len(details))
+	}
+
+	d := details[0]
+	if d.ReportType != "go" || d.ExceptType != "panic" || d.Signal != "SIGSEGV" {
+		t.Errorf("crash = %+v, want a go panic with SIGSEGV", d.CrashReport)
+	}
+	if d.Message != "runtime error: invalid memory address or nil pointer dereference" {
+		t.Errorf("Message = %q", d.Message)
+	}
+	if d.Process != "api-server" || d.Line != 3 || d.CrashThread != 34 {
+		t.Errorf("Process, Line, CrashThread = %q, %d, %d, want api-server, 3, 34", d.Process, d.Line, d.CrashThread)
+	}
+	wantFrames := []string{
+		"main.(*Server).handle (server.go:42)",
+		"net/http.HandlerFunc.ServeHTTP (server.go:2136)",
+		"main.main (main.go:17)",
+	}
+	if strings.Join(d.Backtrace, "|") != strings.Join(wantFrames, "|") {
+		t.Errorf("Backtrace = %q, want %q", d.Backtrace, wantFrames)
+	}
+	want := time.Date(2026, 2, 10, 14, 30, 0, 0, time.Local)
+	if ts, err := time.Parse(time.RFC3339, d.Timestamp); err != nil || !ts.Equal(want) {
+		t.Errorf("Timestamp = %q, want the preceding log line's time %v", d.Timestamp, want)
+	}
+
+	f := details[1]
+	if f.ExceptType != "fatal error" || f.Message != "concurrent map writes" || f.Line != 21 {
+		t.Errorf("second crash = %+v (%q), want fatal error at line 21", f.CrashReport, f.Message)
+	}
+}
+
+func TestParseCrashLog_LongLinesAndLargeFiles(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(strings.Repeat("x", 2*1024*1024) + "\n")
+	for i := 0; i < 3*maxCrashLines; i++ {
+		b.WriteString("2026/02/10 14:29:58 request ok\n")
+	}
+	b.WriteString(sampleGoLog)
+	path := writeLog(t, t.TempDir(), "api-server.log", b.String())
+
+	details, err := parseCrashLog(path)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(details) != 2 {
+		t.Fatalf("parseCrashLog() returned %d crashes, want 2 despite a 2MB line", len(details))
+	}
+	if want := 1 + 3*maxCrashLines + 3; details[0].Line != want || details[0].Signal != "SIGSEGV" {
+		t.Errorf("Line, Signal = %d, %q, want %d, SIGSEGV", details[0].Line, details[0].Signal, want)
+	}
+	if want := 1 + 3*maxCrashLines + 21; details[1].Line != want {
+		t.Errorf("second crash Line = %d, want %d", details[1].Line, want)
+	}
+}
+
+func TestParseCrashLog_Cache(t *testing.T) {
+	path := writeLog(t, t.TempDir(), "api-server.log", sampleGoLog)
+
+	first, err := parseCrashLog(path)
+	if err != nil || len(first) != 2 {
+		t.Fatalf("parseCrashLog() = %d crashes, %v, want 2", len(first), err)
+	}
+	// Changing a returned detail must not change the cached one.
+	first[0].Message = "changed"
+	again, err := parseCrashLog(path)
+	if err != nil || len(again) != 2 || again[0].Message == "changed" {
+		t.Fatalf("parseCrashLog() of an unchanged file = %+v, %v, want the cached crashes", again, err)
+	}
+
+	// A file that changed is parsed again.
+	writeLog(t, filepath.Dir(path), "api-server.log", "all good\n")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	details, err := parseCrashLog(path)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(details) != 0 {
+		t.Errorf("parseCrashLog() after a rewrite = %d crashes, want 0", len(details))
+	}
+}
+
+func TestParseCrashLog_NotACrash(t *testing.T) {
+	log := "panic: this line is not followed by a goroutine dump\nall good\n"
+	path := writeLog(t, t.TempDir(), "app.log", log)
+	details, err := parseCrashLog(path)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(details) != 0 {
+		t.Errorf("parseCrashLog() = %+v, want no crashes", details)
+	}
+}
+
+func TestParseCrashLog_Python(t *testing.T) {
+	path := writeLog(t, t.TempDir(), "jobs.log", samplePythonLog)
+
+	details, err := parseCrashLog(path)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(details) != 1 {
+		t.Fatalf("parseCrashLog() returned %d crashes, want 1 for a chained exception", len(details))
+	}
+	d := details[0]
+	if d.ReportType != "python" || d.ExceptType != "jobs.errors.JobError" || d.Message != "job 17 failed" {
+		t.Errorf("crash = %+v (%q), want the last exception of the chain", d.CrashReport, d.Message)
+	}
+	if d.Process != "worker.py" || d.Line != 3 {
+		t.Errorf("Process, Line = %q, %d, want worker.py, 3", d.Process, d.Line)
+	}
+	if len(d.Backtrace) != 2 || d.Backtrace[0] != "__init__ (errors.py:12)" {
+		t.Errorf("Backtrace = %q, want innermost frame first", d.Backtrace)
+	}
+	if d.RawTimestamp != "2026-02-10 09:15:03,481" {
+		t.Errorf("RawTimestamp = %q", d.RawTimestamp)
+	}
+}
+
+func TestParseJVMDetail(t *testing.T) {
+	path := writeLog(t, t.TempDir(), "hs_err_pid4321.log", sampleHsErr)
+	if got := crashReportType(filepath.Base(path)); got != "jvm" {
+		t.Fatalf("crashReportType() = %q, want jvm", got)
+	}
+
+	d, err := GetCrashDetail(path)
+	if err != nil {
+		t.Fatalf("GetCrashDetail() error: %v", err)
+	}
+	if d.ReportType != "jvm" || d.Signal != "SIGSEGV" || d.ExceptType != "fatal error" || d.PID != 4321 {
+		t.Errorf("crash = %+v, want jvm SIGSEGV for PID 4321", d.CrashReport)
+	}
+	if d.Process != "com.example.ingest.Main" {
+		t.Errorf("Process = %q, want main class", d.Process)
+	}
+	if d.Version != "17.0.2+8-86" {
+		t.Errorf("Version = %q", d.Version)
+	}
+	if !strings.HasPrefix(d.Message, "C  [libc.so.6+0x18b7a5]") {
+		t.Errorf("Message = %q, want the problematic frame", d.Message)
+	}
+	if d.Timestamp != "2026-02-10T14:30:00Z" {
+		t.Errorf("Timestamp = %q", d.Timestamp)
+	}
+	if len(d.Backtrace) != 4 || d.Backtrace[1] != "j com.example.ingest.Buffer.copy([BI)V+12" {
+		t.Errorf("Backtrace = %q, want native frames", d.Backtrace)
+	}
+}
+
+func TestJavaMainName(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"-Xmx2g -cp app.jar com.example.Main --port 9000", "com.example.Main"},
+		{"-Dfoo=bar -jar /opt/svc/ingest-1.2.jar serve", "ingest-1.2.jar"},
+		{"-Xmx2g", ""},
+	}
+	for _, tt := range tests {
+		if got := javaMainName(tt.args); got != tt.want {
+			t.Errorf("javaMainName(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestQueryCrashReports_Logs(t *testing.T) {
+	dir := t.TempDir()
+	logs := filepath.Join(dir, "logs")
+	if err := os.Mkdir(logs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLog(t, logs, "api-server.log", sampleGoLog)
+	writeLog(t, logs, "jobs.log", samplePythonLog)
+	writeLog(t, dir, "hs_err_pid4321.log", sampleHsErr)
+
+	reports := QueryCrashReports(CrashQuery{Dirs: []string{dir}, Logs: []string{filepath.Join(logs, "*.log")}})
+	if len(reports) != 4 {
+		t.Fatalf("QueryCrashReports() returned %d reports, want 4: %+v", len(reports), reports)
+	}
+	types := make(map[string]int)
+	for _, r := range reports {
+		types[r.ReportType]++
+	}
+	if types["go"] != 2 || types["python"] != 1 || types["jvm"] != 1 {
+		t.Errorf("report types = %v", types)
+	}
+
+	only := QueryCrashReports(CrashQuery{Logs: []string{filepath.Join(logs, "*.log")}, Process: "worker.py"})
+	if len(only) != 1 || only[0].Location() != filepath.Join(logs, "jobs.log")+":3" {
+		t.Errorf("Process filter = %+v, want the Python crash at jobs.log:3", only)
+	}
+}
+
+func TestGetCrashDetail_LogLocation(t *testing.T) {
+	path := writeLog(t, t.TempDir(), "api-server.log", sampleGoLog)
+
+	d, err := GetCrashDetail(path + ":3")
+	if err != nil {
+		t.Fatalf("GetCrashDetail(:3) error: %v", err)
+	}
+	if d.Line != 3 || d.ExceptType != "panic" {
+		t.Errorf("detail = %+v, want the panic at line 3", d.CrashReport)
+	}
+
+	last, err := GetCrashDetail(path)
+	if err != nil {
+		t.Fatalf("GetCrashDetail() error: %v", err)
+	}
+	if last.Line != 21 {
+		t.Errorf("Line = %d, want the last crash (21)", last.Line)
+	}
+
+	if _, err := GetCrashDetail(path + ":5"); err == nil {
+		t.Error("GetCrashDetail(:5) should fail when no crash starts there")
+	}
+}
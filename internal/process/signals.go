@@ -0,0 +1,56 @@
+package process
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// Signal describes a signal that pstop can send to a process.
+type Signal struct {
+	Sig  syscall.Signal
+	Name string // SIG-prefixed, e.g. "SIGTERM"
+	Desc string
+}
+
+// signals are the signals pstop can send, most commonly used first.
+var signals = []Signal{
+	{syscall.SIGTERM, "SIGTERM", "Terminate gracefully (default for kill)"},
+	{syscall.SIGKILL, "SIGKILL", "Kill immediately; cannot be caught or ignored"},
+	{syscall.SIGHUP, "SIGHUP", "Hang up; many daemons reload their configuration"},
+	{syscall.SIGINT, "SIGINT", "Interrupt, as if Ctrl-C was pressed"},
+	{syscall.SIGQUIT, "SIGQUIT", "Quit and dump core; Go and Java print all thread stacks"},
+	{syscall.SIGUSR1, "SIGUSR1", "User-defined; often dumps state or reopens log files"},
+	{syscall.SIGUSR2, "SIGUSR2", "User-defined; often triggers a graceful restart"},
+	{syscall.SIGSTOP, "SIGSTOP", "Pause; cannot be caught or ignored"},
+	{syscall.SIGCONT, "SIGCONT", "Resume a paused process"},
+	{syscall.SIGTSTP, "SIGTSTP", "Stop from the terminal, as if Ctrl-Z was pressed"},
+	{syscall.SIGWINCH, "SIGWINCH", "Terminal window size changed; redraws terminal apps"},
+	{syscall.SIGALRM, "SIGALRM", "Timer expired"},
+}
+
+// Signals returns the signals pstop can send, most commonly used first.
+func Signals() []Signal {
+	return append([]Signal(nil), signals...)
+}
+
+// SignalName returns the SIG-prefixed name of sig, e.g. "SIGTERM".
+func SignalName(sig syscall.Signal) string {
+	for _, s := range signals {
+		if s.Sig == sig {
+			return s.Name
+		}
+	}
+	return fmt.Sprintf("signal %d", int(sig))
+}
+
+// ParseSignal parses a signal name such as "SIGTERM", "term", or "HUP".
+func ParseSignal(s string) (syscall.Signal, error) {
+	name := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "SIG")
+	for _, sig := range signals {
+		if sig.Name == "SIG"+name {
+			return sig.Sig, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown signal: %s", name)
+}
@@ -0,0 +1,41 @@
+package process
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    syscall.Signal
+		wantErr bool
+	}{
+		{"SIGTERM", syscall.SIGTERM, false},
+		{"term", syscall.SIGTERM, false},
+		{"Sighup", syscall.SIGHUP, false},
+		{"WINCH", syscall.SIGWINCH, false},
+		{"SIGBOGUS", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSignal(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSignal(%q) = %v, %v, want %v, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSignalName(t *testing.T) {
+	for _, s := range Signals() {
+		if got := SignalName(s.Sig); got != s.Name {
+			t.Errorf("SignalName(%d) = %q, want %q", int(s.Sig), got, s.Name)
+		}
+		if sig, err := ParseSignal(s.Name); err != nil || sig != s.Sig {
+			t.Errorf("ParseSignal(%q) = %v, %v, want %v", s.Name, sig, err, s.Sig)
+		}
+	}
+	if got := SignalName(syscall.Signal(99)); got != "signal 99" {
+		t.Errorf("SignalName(99) = %q, want \"signal 99\"", got)
+	}
+}
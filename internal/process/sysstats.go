@@ -0,0 +1,333 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SysStats is a snapshot of system-wide resource usage.
+type SysStats struct {
+	Time          time.Time  `json:"time"`
+	UptimeSeconds float64    `json:"uptime_seconds"`
+	Load          [3]float64 `json:"load_avg"`        // 1, 5, and 15 minute load averages
+	CPU           float64    `json:"cpu"`             // busy percent over all cores
+	Cores         []float64  `json:"cores,omitempty"` // busy percent per core, where the OS reports it
+	MemTotal      int64      `json:"mem_total_kb"`
+	MemUsed       int64      `json:"mem_used_kb"`
+	MemFree       int64      `json:"mem_free_kb"` // available to new allocations, including reclaimable caches
+	SwapTotal     int64      `json:"swap_total_kb"`
+	SwapUsed      int64      `json:"swap_used_kb"`
+	Processes     int        `json:"processes"`
+	Threads       int        `json:"threads"`
+}
+
+// Uptime returns how long the system has been up.
+func (s SysStats) Uptime() time.Duration {
+	return time.Duration(s.UptimeSeconds * float64(time.Second))
+}
+
+// MemPercent returns used memory as a percentage of the total.
+func (s SysStats) MemPercent() float64 {
+	return percentOf(s.MemUsed, s.MemTotal)
+}
+
+// SwapPercent returns used swap as a percentage of the total.
+func (s SysStats) SwapPercent() float64 {
+	return percentOf(s.SwapUsed, s.SwapTotal)
+}
+
+func percentOf(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
+
+// cpuTicks are the cumulative busy and total clock ticks of one CPU.
+type cpuTicks struct {
+	busy, total uint64
+}
+
+// SysSampler collects SysStats. CPU usage is computed from the counters
+// read by the previous call to Sample, so the first sample on Linux reports
+// the average since boot. It is safe for concurrent use.
+type SysSampler struct {
+	mu   sync.Mutex
+	prev []cpuTicks
+}
+
+// NewSysSampler returns a sampler with no previous sample.
+func NewSysSampler() *SysSampler {
+	return &SysSampler{}
+}
+
+// Sample returns the current system stats. On Linux they are read from
+// /proc; elsewhere from sysctl, vm_stat, top, and ps.
+func (s *SysSampler) Sample() (*SysStats, error) {
+	if data, err := os.ReadFile("/proc/stat"); err == nil {
+		return s.sampleProc(string(data))
+	}
+	return sampleDarwin()
+}
+
+func (s *SysSampler) sampleProc(stat string) (*SysStats, error) {
+	stats := &SysStats{Time: time.Now()}
+
+	ticks := parseProcStat(stat)
+	if len(ticks) == 0 {
+		return nil, fmt.Errorf("failed to parse /proc/stat")
+	}
+	s.mu.Lock()
+	prev := s.prev
+	s.prev = ticks
+	s.mu.Unlock()
+	if len(prev) != len(ticks) {
+		prev = make([]cpuTicks, len(ticks))
+	}
+	stats.CPU = busyPercent(prev[0], ticks[0])
+	for i := 1; i < len(ticks); i++ {
+		stats.Cores = append(stats.Cores, busyPercent(prev[i], ticks[i]))
+	}
+
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory stats: %w", err)
+	}
+	mem := parseMeminfo(string(data))
+	stats.MemTotal = mem["MemTotal"]
+	avail, ok := mem["MemAvailable"]
+	if !ok {
+		avail = mem["MemFree"] + mem["Buffers"] + mem["Cached"]
+	}
+	stats.MemFree = avail
+	stats.MemUsed = stats.MemTotal - avail
+	stats.SwapTotal = mem["SwapTotal"]
+	stats.SwapUsed = mem["SwapTotal"] - mem["SwapFree"]
+
+	if data, err := os.ReadFile("/proc/loadavg"); err == nil {
+		stats.Load, stats.Threads = parseLoadavg(string(data))
+	}
+	if data, err := os.ReadFile("/proc/uptime"); err == nil {
+		if fields := strings.Fields(string(data)); len(fields) > 0 {
+			stats.UptimeSeconds, _ = strconv.ParseFloat(fields[0], 64)
+		}
+	}
+	if entries, err := os.ReadDir("/proc"); err == nil {
+		for _, e := range entries {
+			if _, err := strconv.Atoi(e.Name()); err == nil && e.IsDir() {
+				stats.Processes++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// busyPercent returns the share of ticks between prev and cur that were
+// spent busy.
+func busyPercent(prev, cur cpuTicks) float64 {
+	if cur.total <= prev.total || cur.busy < prev.busy {
+		return 0
+	}
+	return float64(cur.busy-prev.busy) / float64(cur.total-prev.total) * 100
+}
+
+// parseProcStat reads the cpu lines of /proc/stat. The first element is the
+// aggregate of all CPUs, followed by one per core. Idle and iowait time
+// count as not busy.
+func parseProcStat(data string) []cpuTicks {
+	var ticks []cpuTicks
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		var t cpuTicks
+		// Fields after steal (guest time) are already included in user.
+		for i, f := range fields[1:min(len(fields), 9)] {
+			n, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				break
+			}
+			t.total += n
+			if i != 3 && i != 4 { // idle, iowait
+				t.busy += n
+			}
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+// parseMeminfo reads /proc/meminfo into values in KB.
+func parseMeminfo(data string) map[string]int64 {
+	mem := make(map[string]int64)
+	for _, line := range strings.Split(data, "\n") {
+		key, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if n, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+			mem[key] = n
+		}
+	}
+	return mem
+}
+
+// parseLoadavg reads /proc/loadavg, e.g. "0.52 0.58 0.59 2/1203 48151",
+// returning the load averages and the number of threads.
+func parseLoadavg(data string) ([3]float64, int) {
+	var load [3]float64
+	fields := strings.Fields(data)
+	for i := 0; i < 3 && i < len(fields); i++ {
+		load[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+	var threads int
+	if len(fields) > 3 {
+		if _, total, ok := strings.Cut(fields[3], "/"); ok {
+			threads, _ = strconv.Atoi(total)
+		}
+	}
+	return load, threads
+}
+
+// sysctlKeys are read in one call on macOS, in this order.
+var sysctlKeys = []string{"vm.loadavg", "hw.memsize", "vm.swapusage", "kern.boottime", "hw.ncpu"}
+
+func sampleDarwin() (*SysStats, error) {
+	stats := &SysStats{Time: time.Now()}
+
+	out, err := exec.Command("sysctl", append([]string{"-n"}, sysctlKeys...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system stats: %w", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < len(sysctlKeys) {
+		return nil, fmt.Errorf("failed to read system stats: unexpected sysctl output")
+	}
+	stats.Load = parseSysctlLoadavg(lines[0])
+	memBytes, _ := strconv.ParseInt(strings.TrimSpace(lines[1]), 10, 64)
+	stats.MemTotal = memBytes / 1024
+	stats.SwapTotal, stats.SwapUsed = parseSwapUsage(lines[2])
+	if boot, err := parseBoottime(lines[3]); err == nil {
+		stats.UptimeSeconds = stats.Time.Sub(boot).Seconds()
+	}
+	ncpu, _ := strconv.Atoi(strings.TrimSpace(lines[4]))
+
+	if out, err := exec.Command("vm_stat").Output(); err == nil {
+		stats.MemUsed = parseVMStatUsed(string(out))
+		stats.MemFree = max(stats.MemTotal-stats.MemUsed, 0)
+	}
+	if out, err := exec.Command("top", "-l", "1", "-n", "0").Output(); err == nil {
+		stats.Processes, stats.Threads = parseTopCounts(string(out))
+	}
+	// ps reports per-process CPU relative to one core.
+	if out, err := exec.Command("ps", "-A", "-o", "%cpu=").Output(); err == nil && ncpu > 0 {
+		var sum float64
+		for _, f := range strings.Fields(string(out)) {
+			v, _ := strconv.ParseFloat(f, 64)
+			sum += v
+		}
+		stats.CPU = min(sum/float64(ncpu), 100)
+	}
+	return stats, nil
+}
+
+// parseSysctlLoadavg parses vm.loadavg, e.g. "{ 1.83 1.91 2.01 }".
+func parseSysctlLoadavg(s string) [3]float64 {
+	var load [3]float64
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(s), "{}"))
+	for i := 0; i < 3 && i < len(fields); i++ {
+		load[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+	return load
+}
+
+var swapUsageRe = regexp.MustCompile(`(total|used) = ([\d.]+)([KMG])`)
+
+// parseSwapUsage parses vm.swapusage, e.g.
+// "total = 2048.00M  used = 1093.25M  free = 954.75M  (encrypted)",
+// returning total and used swap in KB.
+func parseSwapUsage(s string) (total, used int64) {
+	for _, m := range swapUsageRe.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			continue
+		}
+		switch m[3] {
+		case "M":
+			v *= 1024
+		case "G":
+			v *= 1024 * 1024
+		}
+		if m[1] == "total" {
+			total = int64(v)
+		} else {
+			used = int64(v)
+		}
+	}
+	return total, used
+}
+
+var boottimeRe = regexp.MustCompile(`sec = (\d+)`)
+
+// parseBoottime parses kern.boottime, e.g.
+// "{ sec = 1760000000, usec = 52301 } Thu Oct  9 10:13:20 2025".
+func parseBoottime(s string) (time.Time, error) {
+	m := boottimeRe.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("unrecognized boot time: %q", s)
+	}
+	sec, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, 0), nil
+}
+
+var vmStatPageSizeRe = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// parseVMStatUsed returns used memory in KB from vm_stat output, counted
+// the way Activity Monitor does: active, wired, and compressed pages.
+func parseVMStatUsed(out string) int64 {
+	pageSize := int64(4096)
+	if m := vmStatPageSizeRe.FindStringSubmatch(out); m != nil {
+		pageSize, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+	var pages int64
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Pages active", "Pages wired down", "Pages occupied by compressor":
+			n, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+			pages += n
+		}
+	}
+	return pages * pageSize / 1024
+}
+
+var topCountsRe = regexp.MustCompile(`Processes: (\d+) total.*?(\d+) threads`)
+
+// parseTopCounts reads the process and thread counts from the header of
+// macOS top, e.g. "Processes: 612 total, 2 running, 610 sleeping, 2874 threads".
+func parseTopCounts(out string) (procs, threads int) {
+	m := topCountsRe.FindStringSubmatch(out)
+	if m == nil {
+		return 0, 0
+	}
+	procs, _ = strconv.Atoi(m[1])
+	threads, _ = strconv.Atoi(m[2])
+	return procs, threads
+}
@@ -0,0 +1,125 @@
+package process
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := `cpu  400 0 100 1400 100 0 0 0 0 0
+cpu0 300 0 50 600 50 0 0 0 0 0
+cpu1 100 0 50 800 50 0 0 0 0 0
+intr 12345
+ctxt 67890
+procs_running 2
+`
+	ticks := parseProcStat(data)
+	if len(ticks) != 3 {
+		t.Fatalf("parseProcStat() returned %d entries, want 3", len(ticks))
+	}
+	if ticks[0].busy != 500 || ticks[0].total != 2000 {
+		t.Errorf("aggregate = %+v, want busy 500 of 2000", ticks[0])
+	}
+	if ticks[1].busy != 350 || ticks[1].total != 1000 {
+		t.Errorf("cpu0 = %+v, want busy 350 of 1000", ticks[1])
+	}
+}
+
+func TestBusyPercent(t *testing.T) {
+	prev := cpuTicks{busy: 100, total: 1000}
+	cur := cpuTicks{busy: 150, total: 1100}
+	if got := busyPercent(prev, cur); got != 50 {
+		t.Errorf("busyPercent() = %v, want 50", got)
+	}
+	if got := busyPercent(cur, cur); got != 0 {
+		t.Errorf("busyPercent(no change) = %v, want 0", got)
+	}
+}
+
+func TestParseMeminfo(t *testing.T) {
+	data := `MemTotal:       16303248 kB
+MemFree:         1191560 kB
+MemAvailable:    9812344 kB
+SwapTotal:       2097148 kB
+SwapFree:        1572860 kB
+HugePages_Total:       0
+`
+	mem := parseMeminfo(data)
+	if mem["MemTotal"] != 16303248 || mem["MemAvailable"] != 9812344 || mem["SwapFree"] != 1572860 {
+		t.Errorf("parseMeminfo() = %v", mem)
+	}
+	if mem["HugePages_Total"] != 0 {
+		t.Errorf("HugePages_Total = %d, want 0", mem["HugePages_Total"])
+	}
+}
+
+func TestParseLoadavg(t *testing.T) {
+	load, threads := parseLoadavg("0.52 0.58 0.59 2/1203 48151\n")
+	if load != [3]float64{0.52, 0.58, 0.59} || threads != 1203 {
+		t.Errorf("parseLoadavg() = %v, %d, want [0.52 0.58 0.59], 1203", load, threads)
+	}
+}
+
+func TestParseSysctlOutputs(t *testing.T) {
+	if got := parseSysctlLoadavg("{ 1.83 1.91 2.01 }"); got != [3]float64{1.83, 1.91, 2.01} {
+		t.Errorf("parseSysctlLoadavg() = %v", got)
+	}
+
+	total, used := parseSwapUsage("total = 2048.00M  used = 1093.25M  free = 954.75M  (encrypted)")
+	if total != 2097152 || used != 1119488 {
+		t.Errorf("parseSwapUsage() = %d, %d, want 2097152, 1119488", total, used)
+	}
+
+	boot, err := parseBoottime("{ sec = 1760000000, usec = 52301 } Thu Oct  9 10:13:20 2025")
+	if err != nil || boot.Unix() != 1760000000 {
+		t.Errorf("parseBoottime() = %v, %v", boot, err)
+	}
+	if _, err := parseBoottime("garbage"); err == nil {
+		t.Error("parseBoottime(garbage) should fail")
+	}
+}
+
+func TestParseVMStatUsed(t *testing.T) {
+	out := `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               12000.
+Pages active:                            200000.
+Pages inactive:                          190000.
+Pages speculative:                         5000.
+Pages wired down:                        100000.
+Pages occupied by compressor:             50000.
+`
+	if got, want := parseVMStatUsed(out), int64(350000*16); got != want {
+		t.Errorf("parseVMStatUsed() = %d, want %d", got, want)
+	}
+}
+
+func TestParseTopCounts(t *testing.T) {
+	out := `Processes: 612 total, 2 running, 610 sleeping, 2874 threads
+2026/10/18 10:00:00
+Load Avg: 1.83, 1.91, 2.01
+`
+	procs, threads := parseTopCounts(out)
+	if procs != 612 || threads != 2874 {
+		t.Errorf("parseTopCounts() = %d, %d, want 612, 2874", procs, threads)
+	}
+}
+
+func TestSysSampler(t *testing.T) {
+	s := NewSysSampler()
+	if _, err := s.Sample(); err != nil {
+		t.Skipf("system stats unavailable: %v", err)
+	}
+	stats, err := s.Sample()
+	if err != nil {
+		t.Fatalf("Sample() error: %v", err)
+	}
+	if stats.MemTotal <= 0 || stats.MemUsed < 0 || stats.MemUsed > stats.MemTotal {
+		t.Errorf("memory = %d used of %d", stats.MemUsed, stats.MemTotal)
+	}
+	if stats.CPU < 0 || stats.CPU > 100 || math.IsNaN(stats.CPU) {
+		t.Errorf("CPU = %v, want 0-100", stats.CPU)
+	}
+	if stats.Processes == 0 {
+		t.Error("Processes = 0")
+	}
+}
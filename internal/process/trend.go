@@ -0,0 +1,73 @@
+package process
+
+import "time"
+
+// Trend describes a least-squares linear fit over a series of samples.
+type Trend struct {
+	Slope     float64 `json:"slope"`     // change in value per second
+	RSquared  float64 `json:"r_squared"` // goodness of fit, 0-1
+	Monotonic float64 `json:"monotonic"` // fraction of steps that did not decrease, 0-1
+}
+
+// Confidence combines how linear and how steady the growth is into a single
+// 0-1 score. Noisy or sawtooth series score low even with a positive slope.
+func (t Trend) Confidence() float64 {
+	if t.Slope <= 0 {
+		return 0
+	}
+	return t.RSquared * t.Monotonic
+}
+
+// FitTrend fits a line to values sampled at times. Both slices must have the
+// same length; fewer than two samples yield a zero Trend.
+func FitTrend(times []time.Time, values []float64) Trend {
+	n := len(values)
+	if n < 2 || len(times) != n {
+		return Trend{}
+	}
+
+	start := times[0]
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := times[i].Sub(start).Seconds()
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+
+	fn := float64(n)
+	denom := fn*sumXX - sumX*sumX
+	if denom == 0 {
+		return Trend{}
+	}
+	slope := (fn*sumXY - sumX*sumY) / denom
+	intercept := (sumY - slope*sumX) / fn
+
+	meanY := sumY / fn
+	var ssTot, ssRes float64
+	nonDecreasing := 0
+	for i, v := range values {
+		x := times[i].Sub(start).Seconds()
+		pred := intercept + slope*x
+		ssTot += (v - meanY) * (v - meanY)
+		ssRes += (v - pred) * (v - pred)
+		if i > 0 && v >= values[i-1] {
+			nonDecreasing++
+		}
+	}
+
+	r2 := 0.0
+	if ssTot > 0 {
+		r2 = 1 - ssRes/ssTot
+		if r2 < 0 {
+			r2 = 0
+		}
+	}
+
+	return Trend{
+		Slope:     slope,
+		RSquared:  r2,
+		Monotonic: float64(nonDecreasing) / float64(n-1),
+	}
+}
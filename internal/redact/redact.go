@@ -0,0 +1,145 @@
+// Package redact removes personal and machine-identifying information from
+// text such as crash reports.
+package redact
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+)
+
+// Rule replaces every match of Pattern with Replacement. Replacement may
+// refer to capture groups, as in regexp.Regexp.ReplaceAll.
+type Rule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// Identity is the machine-specific information removed by the default rules.
+type Identity struct {
+	Home string
+	User string
+	Host string
+}
+
+// CurrentIdentity returns the home directory, user name, and host name of
+// the current machine. Values that cannot be determined are left empty.
+func CurrentIdentity() Identity {
+	var id Identity
+	id.Home, _ = os.UserHomeDir()
+	if u, err := user.Current(); err == nil {
+		id.User = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		id.Host = host
+	}
+	return id
+}
+
+// serialRegex matches hardware serial numbers and similar identifiers as they
+// appear in crash and panic reports, e.g. `"serialNumber":"C02XK0ABJGH5"` or
+// "Serial Number (system): C02XK0ABJGH5".
+var serialRegex = regexp.MustCompile(`(?i)((?:serial[ _-]?number|serial|crashReporterKey|deviceIdentifierForVendor)(?:\s*\([^)]*\))?["']?\s*[:=]\s*["']?)([A-Za-z0-9-]{8,})`)
+
+// pathSep matches a path separator, which JSON reports such as macOS .ips
+// files may escape as "\/".
+const pathSep = `\\?/`
+
+// homesRegex matches the account name in home directory paths of any user.
+var homesRegex = regexp.MustCompile(`(` + pathSep + `Users` + pathSep + `|` + pathSep + `home` + pathSep + `)([^/\\\s"':]+)`)
+
+// pathPattern returns a pattern matching path with plain or escaped
+// separators.
+func pathPattern(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return strings.Join(parts, pathSep)
+}
+
+// DefaultRules returns rules that replace id's home directory with "~", and
+// its host name, other users' home directories, its user name, and serial
+// numbers with placeholders. Host names are replaced before user names since
+// they often contain them.
+func DefaultRules(id Identity) []Rule {
+	var rules []Rule
+	if id.Home != "" && id.Home != "/" {
+		rules = append(rules, Rule{
+			Name:        "home",
+			Pattern:     regexp.MustCompile(pathPattern(strings.TrimSuffix(id.Home, "/"))),
+			Replacement: "~",
+		})
+	}
+	if id.Host != "" {
+		// Match the short name too, which reports often use.
+		short, _, _ := strings.Cut(id.Host, ".")
+		pattern := regexp.QuoteMeta(id.Host)
+		if short != id.Host {
+			pattern += "|" + regexp.QuoteMeta(short)
+		}
+		rules = append(rules, Rule{
+			Name:        "hostname",
+			Pattern:     regexp.MustCompile(`(?i)\b(?:` + pattern + `)\b`),
+			Replacement: "<hostname>",
+		})
+	}
+	rules = append(rules, Rule{Name: "user", Pattern: homesRegex, Replacement: "${1}<user>"})
+	if id.User != "" {
+		rules = append(rules, Rule{
+			Name:        "user",
+			Pattern:     regexp.MustCompile(`\b` + regexp.QuoteMeta(id.User) + `\b`),
+			Replacement: "<user>",
+		})
+	}
+	rules = append(rules, Rule{Name: "serial", Pattern: serialRegex, Replacement: "${1}<serial>"})
+	return rules
+}
+
+// CompileRules compiles user-supplied regular expressions into rules that
+// replace each match with "<redacted>".
+func CompileRules(patterns []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		rules = append(rules, Rule{Name: "custom", Pattern: re, Replacement: "<redacted>"})
+	}
+	return rules, nil
+}
+
+// Redactor applies a list of rules in order.
+type Redactor struct {
+	rules []Rule
+}
+
+// New returns a Redactor for rules.
+func New(rules []Rule) *Redactor {
+	return &Redactor{rules: rules}
+}
+
+// Redact returns data with every rule applied, and the number of matches
+// replaced per rule name.
+func (r *Redactor) Redact(data []byte) ([]byte, map[string]int) {
+	counts := make(map[string]int)
+	for _, rule := range r.rules {
+		n := len(rule.Pattern.FindAllIndex(data, -1))
+		if n == 0 {
+			continue
+		}
+		counts[rule.Name] += n
+		data = rule.Pattern.ReplaceAll(data, []byte(rule.Replacement))
+	}
+	return data, counts
+}
+
+// RedactString is Redact for strings, without the counts.
+func (r *Redactor) RedactString(s string) string {
+	out, _ := r.Redact([]byte(s))
+	return string(out)
+}
@@ -0,0 +1,102 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultRules(t *testing.T) {
+	id := Identity{Home: "/Users/jdoe", User: "jdoe", Host: "jdoe-mbp.corp.example.com"}
+	r := New(DefaultRules(id))
+
+	input := `{"procPath":"/Users/jdoe/Applications/MyApp.app/Contents/MacOS/MyApp",
+"other":"/Users/alice/Library/x.dylib",
+"note":"run by jdoe on jdoe-mbp",
+"serialNumber":"C02XK0ABJGH5"}
+Serial Number (system): C02XK0ABJGH5
+Host: jdoe-mbp.corp.example.com`
+
+	out, counts := r.Redact([]byte(input))
+	got := string(out)
+
+	for _, leak := range []string{"jdoe", "alice", "C02XK0ABJGH5", "corp.example.com"} {
+		if strings.Contains(got, leak) {
+			t.Errorf("output still contains %q:\n%s", leak, got)
+		}
+	}
+	for _, want := range []string{
+		`"~/Applications/MyApp.app`,
+		`/Users/<user>/Library/x.dylib`,
+		`run by <user> on <hostname>`,
+		`"serialNumber":"<serial>"`,
+		`Serial Number (system): <serial>`,
+		`Host: <hostname>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	want := map[string]int{"home": 1, "user": 2, "hostname": 2, "serial": 2}
+	for name, n := range want {
+		if counts[name] != n {
+			t.Errorf("counts[%s] = %d, want %d (all: %v)", name, counts[name], n, counts)
+		}
+	}
+}
+
+// sampleIPS is an excerpt of a macOS .ips report, which escapes the slashes
+// in paths.
+const sampleIPS = `{"app_name":"MyApp","procPath":"\/Users\/jdoe\/Applications\/MyApp.app\/Contents\/MacOS\/MyApp"}
+{"usedImages":[{"path":"\/Users\/alice\/Library\/Frameworks\/x.dylib"},{"path":"\/home\/bob\/lib\/y.so"}]}`
+
+func TestDefaultRules_EscapedPaths(t *testing.T) {
+	r := New(DefaultRules(Identity{Home: "/Users/jdoe", User: "jdoe"}))
+
+	out, counts := r.Redact([]byte(sampleIPS))
+	got := string(out)
+
+	for _, leak := range []string{"jdoe", "alice", "bob"} {
+		if strings.Contains(got, leak) {
+			t.Errorf("output still contains %q:\n%s", leak, got)
+		}
+	}
+	for _, want := range []string{
+		`"procPath":"~\/Applications\/MyApp.app`,
+		`"\/Users\/<user>\/Library\/Frameworks\/x.dylib"`,
+		`"\/home\/<user>\/lib\/y.so"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if counts["home"] != 1 || counts["user"] != 2 {
+		t.Errorf("counts = %v, want home=1 user=2", counts)
+	}
+}
+
+func TestDefaultRules_EmptyIdentity(t *testing.T) {
+	r := New(DefaultRules(Identity{}))
+	out, counts := r.Redact([]byte("nothing to see in /tmp/x"))
+	if string(out) != "nothing to see in /tmp/x" || len(counts) != 0 {
+		t.Errorf("Redact() = %q, %v; want input unchanged", out, counts)
+	}
+}
+
+func TestCompileRules(t *testing.T) {
+	rules, err := CompileRules([]string{`ACME-[0-9]+`})
+	if err != nil {
+		t.Fatalf("CompileRules() error: %v", err)
+	}
+	out, counts := New(rules).Redact([]byte("ticket ACME-123 and ACME-456"))
+	if string(out) != "ticket <redacted> and <redacted>" {
+		t.Errorf("Redact() = %q", out)
+	}
+	if counts["custom"] != 2 {
+		t.Errorf("counts[custom] = %d, want 2", counts["custom"])
+	}
+
+	if _, err := CompileRules([]string{"("}); err == nil {
+		t.Error("CompileRules(invalid) should return error")
+	}
+}
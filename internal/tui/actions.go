@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// signalAction is a signal sent to one or more processes from the TUI.
+type signalAction struct {
+	sig  syscall.Signal
+	verb string // imperative, e.g. "kill"
+	done string // past tense, e.g. "Killed"
+}
+
+var (
+	killAction   = signalAction{sig: syscall.SIGTERM, verb: "kill", done: "Killed"}
+	pauseAction  = signalAction{sig: syscall.SIGSTOP, verb: "pause", done: "Paused"}
+	resumeAction = signalAction{sig: syscall.SIGCONT, verb: "resume", done: "Resumed"}
+)
+
+// signalTarget is a process an action is sent to. started is its start time
+// when the row was listed, so that the signal is not delivered if the PID
+// has been reused since. It is zero if the start time could not be read, and
+// such a target is never signalled.
+type signalTarget struct {
+	pid     int
+	name    string
+	started time.Time
+}
+
+func targetOf(p process.Info) signalTarget {
+	return signalTarget{pid: p.PID, name: p.Name, started: p.Started}
+}
+
+// is reports whether p is the process t was taken from, rather than one
+// that reused its PID.
+func (t signalTarget) is(p process.Info) bool {
+	return t.pid == p.PID && t.name == p.Name && t.started.Equal(p.Started)
+}
+
+// confirmSignalMsg carries the targets of an action, with their start times,
+// for confirmation. gone lists targets that have exited or whose PID has
+// been reused.
+type confirmSignalMsg struct {
+	action  signalAction
+	targets []signalTarget
+	gone    []signalTarget
+}
+
+type signalResult struct {
+	target signalTarget
+	err    error
+}
+
+type signalResultMsg struct {
+	action  signalAction
+	results []signalResult
+}
+
+// prepareSignal checks that each target is still the process that was
+// listed before the action is confirmed. A target without a start time
+// cannot be checked, and is skipped as gone.
+func prepareSignal(action signalAction, targets []signalTarget) tea.Cmd {
+	return func() tea.Msg {
+		msg := confirmSignalMsg{action: action}
+		for _, t := range targets {
+			started, err := process.StartTime(t.pid)
+			if err != nil || t.started.IsZero() || !started.Equal(t.started) {
+				msg.gone = append(msg.gone, t)
+				continue
+			}
+			msg.targets = append(msg.targets, t)
+		}
+		return msg
+	}
+}
+
+// sendSignal sends the action's signal to each target that still has the
+// start time recorded when it was confirmed.
+func sendSignal(action signalAction, targets []signalTarget) tea.Cmd {
+	return func() tea.Msg {
+		results := make([]signalResult, len(targets))
+		for i, t := range targets {
+			results[i] = signalResult{target: t, err: process.KillIfStarted(t.pid, action.sig, t.started)}
+		}
+		return signalResultMsg{action: action, results: results}
+	}
+}
+
+// actionTargets returns the marked processes in display order, or the
+// process under the cursor when none are marked. The error explains why
+// there is nothing to act on.
+func (m Model) actionTargets() ([]signalTarget, error) {
+	if len(m.marked) > 0 {
+		var targets []signalTarget
+		listed := make(map[int]bool)
+		for _, p := range m.filtered {
+			if t, ok := m.marked[p.PID]; ok && t.is(p) && !m.isGhost(p) {
+				targets = append(targets, t)
+				listed[p.PID] = true
+			}
+		}
+		// Marked processes hidden by the filter are still targets.
+		var hidden []int
+		for pid := range m.marked {
+			if !listed[pid] {
+				hidden = append(hidden, pid)
+			}
+		}
+		sort.Ints(hidden)
+		for _, pid := range hidden {
+			targets = append(targets, m.marked[pid])
+		}
+		return targets, nil
+	}
+
+	if m.listLen() == 0 {
+		return nil, fmt.Errorf("no process selected")
+	}
+	if m.selectedGone != "" {
+		return nil, fmt.Errorf("PID %d %s", m.selectedPID, m.selectedGone)
+	}
+	p := m.filtered[m.cursor]
+	return []signalTarget{targetOf(p)}, nil
+}
+
+// toggleMark marks or unmarks the process under the cursor.
+func (m *Model) toggleMark() {
+	if m.tab == TabDev || m.listLen() == 0 || m.selectedGone != "" {
+		return
+	}
+	p := m.filtered[m.cursor]
+	if m.isGhost(p) {
+		return
+	}
+	if _, ok := m.marked[p.PID]; ok {
+		delete(m.marked, p.PID)
+		return
+	}
+	m.marked[p.PID] = targetOf(p)
+}
+
+// markAll marks every process that matches the current filter.
+func (m *Model) markAll() {
+	if m.tab == TabDev {
+		return
+	}
+	for _, p := range m.filtered {
+		if !m.isGhost(p) {
+			m.marked[p.PID] = targetOf(p)
+		}
+	}
+}
+
+// pruneMarks unmarks processes that have exited or whose PID now belongs to
+// a different program.
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	listed := make(map[int]process.Info, len(m.processes))
+	for _, p := range m.processes {
+		listed[p.PID] = p
+	}
+	for pid, t := range m.marked {
+		p, ok := listed[pid]
+		if (ok && !t.is(p)) || (!ok && !process.Alive(pid)) {
+			delete(m.marked, pid)
+		}
+	}
+}
+
+// signalSummary describes the results of an action for the status line.
+func signalSummary(action signalAction, results []signalResult) string {
+	if len(results) == 1 {
+		r := results[0]
+		if r.err != nil {
+			return fmt.Sprintf("Failed to %s PID %d: %v", action.verb, r.target.pid, r.err)
+		}
+		return fmt.Sprintf("%s PID %d", action.done, r.target.pid)
+	}
+
+	var failed []string
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, fmt.Sprintf("%d (%v)", r.target.pid, r.err))
+		}
+	}
+	summary := fmt.Sprintf("%s %d of %d processes", action.done, len(results)-len(failed), len(results))
+	if len(failed) == 0 {
+		return summary
+	}
+	const maxListed = 3
+	if len(failed) > maxListed {
+		failed = append(failed[:maxListed], fmt.Sprintf("and %d more", len(failed)-maxListed))
+	}
+	return summary + "; failed: " + strings.Join(failed, ", ")
+}
+
+// renderConfirm renders the confirmation dialog for a pending action,
+// listing its targets.
+func (m Model) renderConfirm() string {
+	var b strings.Builder
+	targets := m.confirmTargets
+	action := m.confirmAction
+	verb := strings.ToUpper(action.verb[:1]) + action.verb[1:]
+
+	if len(targets) == 1 {
+		t := targets[0]
+		b.WriteString(warnStyle.Render(fmt.Sprintf("%s %s (PID %d)? (y/n)", verb, t.name, t.pid)))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(warnStyle.Render(fmt.Sprintf("%s %d processes? (y/n)", verb, len(targets))))
+		b.WriteString("\n")
+		limit := max(m.tableHeight()-2, 1)
+		for i, t := range targets {
+			if i == limit && len(targets) > limit+1 {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("  ... and %d more", len(targets)-limit)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString(fmt.Sprintf("  %-8d %s\n", t.pid, t.name))
+		}
+	}
+	if len(m.confirmGone) > 0 {
+		pids := make([]string, len(m.confirmGone))
+		for i, t := range m.confirmGone {
+			pids[i] = fmt.Sprintf("%d", t.pid)
+		}
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  Skipping %d no longer running: %s",
+			len(m.confirmGone), strings.Join(pids, ", "))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestSignalSummary(t *testing.T) {
+	ok := func(pid int) signalResult { return signalResult{target: signalTarget{pid: pid}} }
+	failed := func(pid int) signalResult {
+		return signalResult{target: signalTarget{pid: pid}, err: syscall.EPERM}
+	}
+
+	tests := []struct {
+		name    string
+		results []signalResult
+		want    string
+	}{
+		{"one sent", []signalResult{ok(10)}, "Killed PID 10"},
+		{"one failed", []signalResult{failed(10)}, "Failed to kill PID 10: operation not permitted"},
+		{"all sent", []signalResult{ok(10), ok(11)}, "Killed 2 of 2 processes"},
+		{"some failed", []signalResult{ok(10), failed(11), ok(12)},
+			"Killed 2 of 3 processes; failed: 11 (operation not permitted)"},
+		{"many failed", []signalResult{failed(10), failed(11), failed(12), failed(13), failed(14)},
+			"Killed 0 of 5 processes; failed: 10 (operation not permitted), 11 (operation not permitted), " +
+				"12 (operation not permitted), and 2 more"},
+	}
+	for _, tt := range tests {
+		if got := signalSummary(killAction, tt.results); got != tt.want {
+			t.Errorf("%s: signalSummary() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignalTargetIs(t *testing.T) {
+	p := proc(pidA, "a", 0)
+	p.Started = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	target := targetOf(p)
+
+	restarted := p
+	restarted.Started = p.Started.Add(time.Second)
+	renamed := p
+	renamed.Name = "other"
+
+	tests := []struct {
+		name string
+		p    process.Info
+		want bool
+	}{
+		{"same process", p, true},
+		{"pid reused with the same name", restarted, false},
+		{"pid reused by another program", renamed, false},
+		{"other pid", proc(pidB, "a", 0), false},
+	}
+	for _, tt := range tests {
+		if got := target.is(tt.p); got != tt.want {
+			t.Errorf("%s: is() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/columns"
 	"github.com/lu-zhengda/pstop/internal/process"
 )
 
@@ -21,36 +23,34 @@ const (
 	TabDev
 )
 
-// SortColumn represents the column to sort by.
-type SortColumn int
-
-const (
-	SortCPU SortColumn = iota
-	SortMem
-	SortPID
-	SortName
-)
-
-type tickMsg time.Time
+// tabNames are the labels of the tab bar, in Tab order.
+var tabNames = []string{"All", "Top", "Dev"}
 
+// processMsg and devGroupMsg carry the sequence number of the fetch they
+// answer, so that results of superseded fetches are dropped.
 type processMsg struct {
+	seq       int
 	processes []process.Info
 	err       error
+	startErr  error // the start times could not be read
 }
 
 type devGroupMsg struct {
+	seq    int
 	groups []process.DevGroup
 	err    error
 }
 
 type detailMsg struct {
-	info *process.DetailedInfo
-	err  error
+	pid     int
+	info    *process.DetailedInfo
+	err     error
+	refresh bool // periodic update of an open detail view
 }
 
-type killResultMsg struct {
-	pid int
-	err error
+type sysStatsMsg struct {
+	stats *process.SysStats
+	err   error
 }
 
 // keyMap defines key bindings for the TUI.
@@ -69,8 +69,32 @@ type keyMap struct {
 	Sort2    key.Binding
 	Sort3    key.Binding
 	Sort4    key.Binding
+	SortPrev key.Binding
+	SortNext key.Binding
+	Reverse  key.Binding
+	Columns  key.Binding
+	Faster   key.Binding
+	Slower   key.Binding
+	Freeze   key.Binding
+	Refresh  key.Binding
 	PageUp   key.Binding
 	PageDown key.Binding
+	Header   key.Binding
+	Close    key.Binding
+
+	// Marking and batch actions.
+	Mark       key.Binding
+	MarkAll    key.Binding
+	ClearMarks key.Binding
+	Pause      key.Binding
+	Resume     key.Binding
+	Signal     key.Binding
+
+	// Detail view.
+	NextSection key.Binding
+	PrevSection key.Binding
+	Open        key.Binding
+	Back        key.Binding
 }
 
 func newKeyMap() keyMap {
@@ -89,8 +113,30 @@ func newKeyMap() keyMap {
 		Sort2:    key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "sort MEM")),
 		Sort3:    key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "sort PID")),
 		Sort4:    key.NewBinding(key.WithKeys("4"), key.WithHelp("4", "sort Name")),
+		SortPrev: key.NewBinding(key.WithKeys("<"), key.WithHelp("<", "sort previous column")),
+		SortNext: key.NewBinding(key.WithKeys(">"), key.WithHelp(">", "sort next column")),
+		Reverse:  key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "reverse sort")),
+		Columns:  key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "choose columns")),
+		Faster:   key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "refresh faster")),
+		Slower:   key.NewBinding(key.WithKeys("-", "_"), key.WithHelp("-", "refresh slower")),
+		Freeze:   key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "freeze/resume")),
+		Refresh:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh now")),
 		PageUp:   key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("PgUp", "page up")),
 		PageDown: key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("PgDn", "page down")),
+		Header:   key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "toggle header")),
+		Close:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close details")),
+
+		Mark:       key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+		MarkAll:    key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "mark all shown")),
+		ClearMarks: key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "clear marks")),
+		Pause:      key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause")),
+		Resume:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "resume")),
+		Signal:     key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "send signal")),
+
+		NextSection: key.NewBinding(key.WithKeys("tab", "right", "l"), key.WithHelp("tab", "next section")),
+		PrevSection: key.NewBinding(key.WithKeys("shift+tab", "left", "h"), key.WithHelp("shift+tab", "previous section")),
+		Open:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open child")),
+		Back:        key.NewBinding(key.WithKeys("backspace"), key.WithHelp("backspace", "back")),
 	}
 }
 
@@ -101,40 +147,96 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown},
-		{k.Sort1, k.Sort2, k.Sort3, k.Sort4},
+		{k.Sort1, k.Sort2, k.Sort3, k.Sort4, k.SortPrev, k.SortNext, k.Reverse},
 		{k.Kill, k.Info, k.Search, k.Tab},
-		{k.Quit, k.Help},
+		{k.Mark, k.MarkAll, k.ClearMarks, k.Pause, k.Resume, k.Signal},
+		{k.Faster, k.Slower, k.Freeze, k.Refresh},
+		{k.Columns, k.Header, k.Quit, k.Help},
 	}
 }
 
 // Model is the Bubble Tea model for pstop.
 type Model struct {
-	version     string
-	keys        keyMap
-	help        help.Model
-	width       int
-	height      int
-	tab         Tab
-	sort        SortColumn
-	cursor      int
-	offset      int
-	processes   []process.Info
-	devGroups   []process.DevGroup
-	filtered    []process.Info
-	searching   bool
-	searchInput textinput.Model
-	filter      string
-	confirming  bool
-	confirmPID  int
-	showDetail  bool
-	detail      *process.DetailedInfo
-	showHelp    bool
-	err         error
-	statusMsg   string
+	version        string
+	keys           keyMap
+	help           help.Model
+	width          int
+	height         int
+	tab            Tab
+	cols           []columns.Column
+	sortCol        string // column ID
+	sortDesc       bool
+	cursor         int
+	offset         int
+	selectedPID    int
+	selectedName   string
+	selectedGone   string // why the selected process is no longer shown
+	processes      []process.Info
+	devGroups      []process.DevGroup
+	filtered       []process.Info
+	searching      bool
+	searchInput    textinput.Model
+	filter         string
+	confirming     bool
+	confirmAction  signalAction
+	confirmTargets []signalTarget
+	confirmGone    []signalTarget
+	marked         map[int]signalTarget // marked rows by PID
+	pickingSignal  bool
+	signalInput    textinput.Model
+	signalCursor   int
+	lastSignal     syscall.Signal
+	choosingCols   bool
+	chooser        []columns.Column // every column, visible ones first
+	chooserOn      map[string]bool
+	chooserCursor  int
+	showDetail     bool
+	detail         *process.DetailedInfo
+	detailGone     bool // the process in the detail view has exited
+	detailNote     string
+	detailStack    []detailFrame
+	detailSection  detailSection
+	detailScroll   int
+	detailCursor   int
+	history        map[int]*history
+	showHelp       bool
+	err            error
+	statusMsg      string
+	sampler        *process.SysSampler
+	sys            *process.SysStats
+	hideHeader     bool
+	interval       time.Duration
+	frozen         bool
+	tickGen        int
+	fetchSeq       int   // sequence number of the latest list fetch
+	fetching       bool  // the latest list fetch has not arrived yet
+	startErr       error // start times could not be read, so signals are refused
+	churnReady     bool  // m.processes is a snapshot to diff the next one against
+	newProcs       map[procKey]int
+	ghosts         map[procKey]ghost
+	spawned        int
+	exited         int
+}
+
+// Options configures the TUI.
+type Options struct {
+	// Columns are the process table columns. The defaults are used if empty.
+	Columns []columns.Column
+	// Interval is the refresh interval, 2s if zero.
+	Interval time.Duration
 }
 
 // New creates a new TUI model.
-func New(version string) Model {
+func New(version string, opts Options) Model {
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = columns.Default()
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
 	ti := textinput.New()
 	ti.Placeholder = "Search processes..."
 	ti.CharLimit = 64
@@ -143,18 +245,25 @@ func New(version string) Model {
 		version:     version,
 		keys:        newKeyMap(),
 		help:        help.New(),
-		sort:        SortCPU,
+		cols:        cols,
+		sortCol:     "cpu",
+		sortDesc:    true,
 		searchInput: ti,
+		sampler:     process.NewSysSampler(),
+		history:     make(map[int]*history),
+		marked:      make(map[int]signalTarget),
+		signalInput: newSignalInput(),
+		lastSignal:  syscall.SIGTERM,
+		interval:    interval,
+		fetching:    true,
+		newProcs:    make(map[procKey]int),
+		ghosts:      make(map[procKey]ghost),
 	}
 }
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
-}
-
-func fetchProcesses(tab Tab, sort SortColumn) tea.Cmd {
+// fetchProcesses lists the processes of tab along with the extra data cols
+// need. They are sorted by applyFilter.
+func fetchProcesses(tab Tab, cols []columns.Column, seq int) tea.Cmd {
 	return func() tea.Msg {
 		var procs []process.Info
 		var err error
@@ -166,50 +275,40 @@ func fetchProcesses(tab Tab, sort SortColumn) tea.Cmd {
 			procs, err = process.List()
 		}
 		if err != nil {
-			return processMsg{err: err}
+			return processMsg{seq: seq, err: err}
 		}
-		process.Sort(procs, sortColumnToField(sort))
-		return processMsg{processes: procs}
+		columns.Fill(procs, cols)
+		// Exact start times tell a listed or marked process apart from one
+		// that reuses its PID before it is signalled.
+		startErr := process.AddStartTimes(procs)
+		return processMsg{seq: seq, processes: procs, startErr: startErr}
 	}
 }
 
-func fetchDevGroups() tea.Cmd {
+func fetchSysStats(sampler *process.SysSampler) tea.Cmd {
 	return func() tea.Msg {
-		groups, err := process.GroupByStack()
-		return devGroupMsg{groups: groups, err: err}
+		stats, err := sampler.Sample()
+		return sysStatsMsg{stats: stats, err: err}
 	}
 }
 
-func fetchDetail(pid int) tea.Cmd {
+func fetchDevGroups(seq int) tea.Cmd {
 	return func() tea.Msg {
-		info, err := process.GetInfo(pid)
-		return detailMsg{info: info, err: err}
+		groups, err := process.GroupByStack()
+		return devGroupMsg{seq: seq, groups: groups, err: err}
 	}
 }
 
-func killProcess(pid int) tea.Cmd {
+func fetchDetail(pid int, refresh bool) tea.Cmd {
 	return func() tea.Msg {
-		err := process.Kill(pid, false)
-		return killResultMsg{pid: pid, err: err}
-	}
-}
-
-func sortColumnToField(s SortColumn) string {
-	switch s {
-	case SortMem:
-		return "mem"
-	case SortPID:
-		return "pid"
-	case SortName:
-		return "name"
-	default:
-		return "cpu"
+		info, err := process.GetInfo(pid)
+		return detailMsg{pid: pid, info: info, err: err, refresh: refresh}
 	}
 }
 
-// Init initializes the TUI.
+// Init initializes the TUI. New marks the first fetch as in flight.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(fetchProcesses(m.tab, m.sort), tickCmd())
+	return tea.Batch(fetchSysStats(m.sampler), m.listCmd(), tickCmd(m.interval, m.tickGen))
 }
 
 // Update handles messages.
@@ -222,21 +321,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tickMsg:
-		if m.tab == TabDev {
-			return m, tea.Batch(fetchDevGroups(), tickCmd())
+		if msg.gen != m.tickGen || m.frozen {
+			return m, nil
 		}
-		return m, tea.Batch(fetchProcesses(m.tab, m.sort), tickCmd())
+		if m.fetching {
+			// Skip this tick rather than overlap a slow fetch.
+			return m, tickCmd(m.interval, m.tickGen)
+		}
+		cmd := m.refresh()
+		return m, tea.Batch(cmd, tickCmd(m.interval, m.tickGen))
 
 	case processMsg:
+		if msg.seq != m.fetchSeq {
+			return m, nil
+		}
+		m.fetching = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
+		if msg.startErr != nil && m.startErr == nil {
+			m.statusMsg = fmt.Sprintf("Signals disabled: %v", msg.startErr)
+		}
+		m.startErr = msg.startErr
+		m.trackChurn(msg.processes)
 		m.processes = msg.processes
+		m.recordHistory(msg.processes, time.Now())
+		m.pruneMarks()
 		m.applyFilter()
 		return m, nil
 
+	case sysStatsMsg:
+		// The header is optional, so a failure only leaves it hidden.
+		if msg.err == nil {
+			m.sys = msg.stats
+			m.clampCursor()
+		}
+		return m, nil
+
 	case devGroupMsg:
+		if msg.seq != m.fetchSeq {
+			return m, nil
+		}
+		m.fetching = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
@@ -245,25 +372,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case detailMsg:
+		if msg.refresh {
+			// Ignore updates that arrive after the view was closed or
+			// switched to another process.
+			if !m.showDetail || m.detail == nil || m.detail.PID != msg.pid {
+				return m, nil
+			}
+			if msg.err != nil {
+				m.detailGone = true
+				return m, nil
+			}
+			m.detail = msg.info
+			return m, nil
+		}
 		if msg.err != nil {
+			if m.showDetail {
+				m.detailNote = fmt.Sprintf("PID %d is no longer running", msg.pid)
+				return m, nil
+			}
 			m.err = msg.err
 			return m, nil
 		}
-		m.detail = msg.info
-		m.showDetail = true
+		m.openDetail(msg.info)
 		return m, nil
 
-	case killResultMsg:
-		m.confirming = false
-		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("Failed to kill PID %d: %v", msg.pid, msg.err)
-		} else {
-			m.statusMsg = fmt.Sprintf("Killed PID %d", msg.pid)
+	case confirmSignalMsg:
+		if len(msg.targets) == 0 {
+			if len(msg.gone) == 1 {
+				m.statusMsg = fmt.Sprintf("PID %d is no longer running", msg.gone[0].pid)
+			} else {
+				m.statusMsg = fmt.Sprintf("None of the %d processes are still running", len(msg.gone))
+			}
+			cmd := m.fetchList()
+			return m, cmd
 		}
-		return m, fetchProcesses(m.tab, m.sort)
+		m.confirming = true
+		m.confirmAction = msg.action
+		m.confirmTargets = msg.targets
+		m.confirmGone = msg.gone
+		return m, nil
+
+	case signalResultMsg:
+		m.confirming = false
+		m.statusMsg = signalSummary(msg.action, msg.results)
+		cmd := m.fetchList()
+		return m, cmd
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 
 	return m, nil
@@ -279,6 +438,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.applyFilter()
 			m.cursor = 0
 			m.offset = 0
+			m.syncSelection()
 			return m, nil
 		case "esc":
 			m.searching = false
@@ -295,11 +455,21 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// If confirming kill.
+	// If choosing columns.
+	if m.choosingCols {
+		return m.handleChooserKey(msg)
+	}
+
+	// If choosing a signal.
+	if m.pickingSignal {
+		return m.handleSignalKey(msg)
+	}
+
+	// If confirming an action.
 	if m.confirming {
 		switch {
 		case key.Matches(msg, m.keys.Confirm):
-			return m, killProcess(m.confirmPID)
+			return m, sendSignal(m.confirmAction, m.confirmTargets)
 		case key.Matches(msg, m.keys.Cancel):
 			m.confirming = false
 			return m, nil
@@ -307,10 +477,27 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Refresh controls work in the table and the detail view.
+	if !m.showHelp {
+		switch {
+		case key.Matches(msg, m.keys.Faster):
+			cmd := m.setInterval(fasterInterval(m.interval))
+			return m, cmd
+		case key.Matches(msg, m.keys.Slower):
+			cmd := m.setInterval(slowerInterval(m.interval))
+			return m, cmd
+		case key.Matches(msg, m.keys.Freeze):
+			cmd := m.toggleFreeze()
+			return m, cmd
+		case key.Matches(msg, m.keys.Refresh):
+			cmd := m.refresh()
+			return m, cmd
+		}
+	}
+
 	// If showing detail.
 	if m.showDetail {
-		m.showDetail = false
-		return m, nil
+		return m.handleDetailKey(msg)
 	}
 
 	// If showing help.
@@ -330,6 +517,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.offset = m.cursor
 			}
 		}
+		m.syncSelection()
 
 	case key.Matches(msg, m.keys.Down):
 		max := m.listLen() - 1
@@ -340,6 +528,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.offset = m.cursor - viewHeight + 1
 			}
 		}
+		m.syncSelection()
 
 	case key.Matches(msg, m.keys.PageUp):
 		viewHeight := m.tableHeight()
@@ -348,6 +537,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.cursor = 0
 		}
 		m.offset = m.cursor
+		m.syncSelection()
 
 	case key.Matches(msg, m.keys.PageDown):
 		viewHeight := m.tableHeight()
@@ -359,18 +549,43 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.cursor >= m.offset+viewHeight {
 			m.offset = m.cursor - viewHeight + 1
 		}
+		m.syncSelection()
 
 	case key.Matches(msg, m.keys.Kill):
-		if m.tab != TabDev && m.listLen() > 0 {
-			proc := m.filtered[m.cursor]
-			m.confirming = true
-			m.confirmPID = proc.PID
+		return m.requestSignal(killAction)
+
+	case key.Matches(msg, m.keys.Pause):
+		return m.requestSignal(pauseAction)
+
+	case key.Matches(msg, m.keys.Resume):
+		return m.requestSignal(resumeAction)
+
+	case key.Matches(msg, m.keys.Signal):
+		return m.openSignalPicker()
+
+	case key.Matches(msg, m.keys.Mark):
+		m.toggleMark()
+		if m.cursor < m.listLen()-1 {
+			m.cursor++
+			m.clampCursor()
+			m.syncSelection()
 		}
 
+	case key.Matches(msg, m.keys.MarkAll):
+		m.markAll()
+
+	case key.Matches(msg, m.keys.ClearMarks):
+		m.marked = make(map[int]signalTarget)
+
 	case key.Matches(msg, m.keys.Info):
 		if m.tab != TabDev && m.listLen() > 0 {
+			if m.selectedGone != "" {
+				m.statusMsg = fmt.Sprintf("PID %d %s", m.selectedPID, m.selectedGone)
+				return m, nil
+			}
 			proc := m.filtered[m.cursor]
-			return m, fetchDetail(proc.PID)
+			m.detailStack = nil
+			return m, fetchDetail(proc.PID, false)
 		}
 
 	case key.Matches(msg, m.keys.Search):
@@ -379,42 +594,84 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, textinput.Blink
 
 	case key.Matches(msg, m.keys.Tab):
-		m.tab = (m.tab + 1) % 3
-		m.cursor = 0
-		m.offset = 0
-		if m.tab == TabDev {
-			return m, fetchDevGroups()
-		}
-		return m, fetchProcesses(m.tab, m.sort)
+		return m.switchTab((m.tab + 1) % Tab(len(tabNames)))
 
 	case key.Matches(msg, m.keys.Help):
 		m.showHelp = true
 
+	case key.Matches(msg, m.keys.Header):
+		m.hideHeader = !m.hideHeader
+		m.clampCursor()
+
+	case key.Matches(msg, m.keys.Columns):
+		if m.tab != TabDev {
+			m.openChooser()
+		}
+
 	case key.Matches(msg, m.keys.Sort1):
-		m.sort = SortCPU
-		m.applyFilter()
+		m.setSort("cpu")
 	case key.Matches(msg, m.keys.Sort2):
-		m.sort = SortMem
-		m.applyFilter()
+		m.setSort("mem")
 	case key.Matches(msg, m.keys.Sort3):
-		m.sort = SortPID
-		m.applyFilter()
+		m.setSort("pid")
 	case key.Matches(msg, m.keys.Sort4):
-		m.sort = SortName
+		m.setSort("name")
+	case key.Matches(msg, m.keys.SortPrev):
+		m.moveSort(-1)
+	case key.Matches(msg, m.keys.SortNext):
+		m.moveSort(1)
+	case key.Matches(msg, m.keys.Reverse):
+		m.sortDesc = !m.sortDesc
 		m.applyFilter()
 	}
 
 	return m, nil
 }
 
+// switchTab shows tab, starting at the top of its list.
+func (m Model) switchTab(tab Tab) (tea.Model, tea.Cmd) {
+	m.tab = tab
+	m.cursor = 0
+	m.offset = 0
+	m.selectedPID = 0
+	m.selectedName = ""
+	m.selectedGone = ""
+	m.resetChurn()
+	cmd := m.fetchList()
+	return m, cmd
+}
+
+// requestSignal starts an action on the marked processes, or on the
+// selected one when none are marked, by asking for confirmation.
+func (m Model) requestSignal(action signalAction) (tea.Model, tea.Cmd) {
+	if m.tab == TabDev {
+		return m, nil
+	}
+	if m.startErr != nil {
+		// Without start times a reused PID cannot be detected.
+		m.statusMsg = fmt.Sprintf("%s not sent: %v", process.SignalName(action.sig), m.startErr)
+		return m, nil
+	}
+	targets, err := m.actionTargets()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("%s not sent: %v", process.SignalName(action.sig), err)
+		return m, nil
+	}
+	return m, prepareSignal(action, targets)
+}
+
 func (m *Model) applyFilter() {
+	defer m.restoreCursor()
+	sortCol, _ := columns.ByID(m.sortCol)
+	procs := m.withGhosts()
 	if m.filter == "" {
-		m.filtered = m.processes
+		m.filtered = procs
+		columns.Sort(m.filtered, sortCol, m.sortDesc)
 		return
 	}
 	query := strings.ToLower(m.filter)
 	var result []process.Info
-	for _, p := range m.processes {
+	for _, p := range procs {
 		if strings.Contains(strings.ToLower(p.Name), query) ||
 			strings.Contains(strings.ToLower(p.Command), query) ||
 			strings.Contains(strings.ToLower(p.User), query) ||
@@ -423,7 +680,89 @@ func (m *Model) applyFilter() {
 		}
 	}
 	m.filtered = result
-	process.Sort(m.filtered, sortColumnToField(m.sort))
+	columns.Sort(m.filtered, sortCol, m.sortDesc)
+}
+
+// syncSelection selects the process under the cursor after the user moves
+// it.
+func (m *Model) syncSelection() {
+	m.selectedGone = ""
+	if m.tab == TabDev || m.cursor >= len(m.filtered) {
+		m.selectedPID = 0
+		m.selectedName = ""
+		return
+	}
+	p := m.filtered[m.cursor]
+	m.selectedPID = p.PID
+	m.selectedName = p.Name
+	if m.isGhost(p) {
+		m.selectedGone = "has exited"
+	}
+}
+
+// restoreCursor moves the cursor back onto the selected process after the
+// list was refreshed, re-sorted, or filtered. If the process is no longer
+// listed, the cursor stays where it was and selectedGone records why, so
+// that the highlight never silently lands on a different process.
+func (m *Model) restoreCursor() {
+	if m.tab == TabDev {
+		return
+	}
+	if m.selectedPID == 0 {
+		m.clampCursor()
+		m.syncSelection()
+		return
+	}
+	for i, p := range m.filtered {
+		if p.PID == m.selectedPID && p.Name == m.selectedName {
+			// A ghost row keeps the selection in place after the
+			// process exits.
+			m.cursor = i
+			m.selectedGone = ""
+			if m.isGhost(p) {
+				m.selectedGone = "has exited"
+			}
+			m.clampCursor()
+			return
+		}
+	}
+
+	m.clampCursor()
+	for _, p := range m.processes {
+		if p.PID != m.selectedPID {
+			continue
+		}
+		if p.Name != m.selectedName {
+			// The PID was reused by another program.
+			m.selectedGone = "has exited"
+			return
+		}
+		// Still running but hidden by the filter.
+		m.syncSelection()
+		return
+	}
+	if process.Alive(m.selectedPID) {
+		m.selectedGone = "is no longer listed"
+	} else {
+		m.selectedGone = "has exited"
+	}
+}
+
+// clampCursor keeps the cursor within the list and scrolls it into view.
+func (m *Model) clampCursor() {
+	if max := m.listLen() - 1; m.cursor > max {
+		m.cursor = max
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	viewHeight := m.tableHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+viewHeight {
+		m.offset = m.cursor - viewHeight + 1
+	}
 }
 
 func (m Model) listLen() int {
@@ -439,7 +778,7 @@ func (m Model) listLen() int {
 
 func (m Model) tableHeight() int {
 	// Header(1) + tab bar(1) + status(1) + help(2) + border padding(2)
-	overhead := 7
+	overhead := 7 + strings.Count(m.renderHeader(), "\n")
 	h := m.height - overhead
 	if h < 1 {
 		h = 10
@@ -459,10 +798,12 @@ func (m Model) View() string {
 	b.WriteString(titleStyle.Render(fmt.Sprintf("pstop %s", m.version)))
 	b.WriteString("\n")
 
+	// System summary.
+	b.WriteString(m.renderHeader())
+
 	// Tab bar.
-	tabs := []string{"All", "Top", "Dev"}
 	var tabParts []string
-	for i, t := range tabs {
+	for i, t := range tabNames {
 		if Tab(i) == m.tab {
 			tabParts = append(tabParts, activeTabStyle.Render(fmt.Sprintf("[%s]", t)))
 		} else {
@@ -483,8 +824,19 @@ func (m Model) View() string {
 
 	// Confirm dialog.
 	if m.confirming {
-		b.WriteString(warnStyle.Render(fmt.Sprintf("Kill PID %d? (y/n)", m.confirmPID)))
-		b.WriteString("\n")
+		b.WriteString(m.renderConfirm())
+		return b.String()
+	}
+
+	// Column chooser.
+	if m.choosingCols {
+		b.WriteString(m.renderChooser())
+		return b.String()
+	}
+
+	// Signal picker.
+	if m.pickingSignal {
+		b.WriteString(m.renderSignalPicker())
 		return b.String()
 	}
 
@@ -505,6 +857,11 @@ func (m Model) View() string {
 		b.WriteString(m.renderDevView())
 	} else {
 		b.WriteString(m.renderProcessTable())
+		if m.selectedGone != "" {
+			b.WriteString(warnStyle.Render(fmt.Sprintf("Selected process %s (PID %d) %s",
+				m.selectedName, m.selectedPID, m.selectedGone)))
+			b.WriteString("\n")
+		}
 	}
 
 	// Status bar.
@@ -528,19 +885,14 @@ func (m Model) renderProcessTable() string {
 	var b strings.Builder
 
 	// Header with sort indicator.
-	sortIndicator := func(col SortColumn) string {
-		if m.sort == col {
-			return " v"
-		}
-		return ""
-	}
-	header := fmt.Sprintf("%-8s %-20s %-10s %8s%s %8s%s %-6s %-s",
-		"PID", "NAME", "USER",
-		"CPU%", sortIndicator(SortCPU),
-		"MEM%", sortIndicator(SortMem),
-		"STATE", "COMMAND",
-	)
-	b.WriteString(headerStyle.Render(header))
+	titles := make([]string, len(m.cols))
+	for i, c := range m.cols {
+		titles[i] = c.Title
+		if c.ID == m.sortCol {
+			titles[i] += sortIndicator(m.sortDesc)
+		}
+	}
+	b.WriteString(headerStyle.Render("  " + m.tableRow(titles)))
 	b.WriteString("\n")
 
 	viewHeight := m.tableHeight()
@@ -549,16 +901,31 @@ func (m Model) renderProcessTable() string {
 		end = len(m.filtered)
 	}
 
+	now := time.Now()
 	for i := m.offset; i < end; i++ {
 		p := m.filtered[i]
-		line := fmt.Sprintf("%-8d %-20s %-10s %8.1f %8.1f %-6s %-s",
-			p.PID, truncate(p.Name, 20), truncate(p.User, 10),
-			p.CPU, p.Mem, p.State, truncate(p.Command, 40),
-		)
+		mark := " "
+		t, marked := m.marked[p.PID]
+		marked = marked && t.is(p)
+		if marked {
+			mark = "*"
+		}
+		values := make([]string, len(m.cols))
+		for j, c := range m.cols {
+			values[j] = c.Value(p, now)
+		}
+		line := mark + " " + m.tableRow(values)
 
+		exited := m.isGhost(p)
 		switch {
-		case i == m.cursor:
+		case i == m.cursor && (m.selectedGone == "" || exited):
 			line = selectedStyle.Render(line)
+		case marked:
+			line = markedStyle.Render(line)
+		case exited:
+			line = exitedStyle.Render(line)
+		case m.newProcs[keyOf(p)] > 0:
+			line = newStyle.Render(line)
 		case p.CPU > 50:
 			line = highCPUStyle.Render(line)
 		case p.CPU > 20:
@@ -569,8 +936,25 @@ func (m Model) renderProcessTable() string {
 		b.WriteString("\n")
 	}
 
-	// Footer with count.
-	b.WriteString(dimStyle.Render(fmt.Sprintf("  %d processes", len(m.filtered))))
+	// Footer with count, churn, and refresh state.
+	shown := len(m.filtered)
+	for _, p := range m.filtered {
+		if m.isGhost(p) {
+			shown--
+		}
+	}
+	footer := fmt.Sprintf("  %d processes", shown)
+	if len(m.marked) > 0 {
+		footer += fmt.Sprintf(", %d marked", len(m.marked))
+	}
+	footer = dimStyle.Render(footer + " • ")
+	if churn := m.churnStatus(); churn != "" {
+		footer += churn + dimStyle.Render(" • ")
+	}
+	if m.startErr != nil {
+		footer += warnStyle.Render("signals disabled") + dimStyle.Render(" • ")
+	}
+	b.WriteString(footer + m.refreshStatus())
 	b.WriteString("\n")
 
 	return b.String()
@@ -593,52 +977,8 @@ func (m Model) renderDevView() string {
 		}
 		b.WriteString("\n")
 	}
-
-	return b.String()
-}
-
-func (m Model) renderDetail() string {
-	var b strings.Builder
-
-	d := m.detail
-	b.WriteString(titleStyle.Render(fmt.Sprintf("Process Details: %s (PID %d)", d.Name, d.PID)))
-	b.WriteString("\n\n")
-
-	b.WriteString(labelStyle.Render("User:       "))
-	b.WriteString(d.User)
-	b.WriteString("\n")
-	b.WriteString(labelStyle.Render("CPU:        "))
-	b.WriteString(fmt.Sprintf("%.1f%%", d.CPU))
-	b.WriteString("\n")
-	b.WriteString(labelStyle.Render("Memory:     "))
-	b.WriteString(fmt.Sprintf("%.1f%%", d.Mem))
-	b.WriteString("\n")
-	b.WriteString(labelStyle.Render("Open Files: "))
-	b.WriteString(fmt.Sprintf("%d", d.OpenFiles))
-	b.WriteString("\n")
-
-	if len(d.Ports) > 0 {
-		b.WriteString(labelStyle.Render("Ports:      "))
-		ports := make([]string, len(d.Ports))
-		for i, p := range d.Ports {
-			ports[i] = fmt.Sprintf("%d", p)
-		}
-		b.WriteString(strings.Join(ports, ", "))
-		b.WriteString("\n")
-	}
-
-	if len(d.Children) > 0 {
-		b.WriteString(labelStyle.Render("Children:   "))
-		children := make([]string, len(d.Children))
-		for i, c := range d.Children {
-			children[i] = fmt.Sprintf("%d", c)
-		}
-		b.WriteString(strings.Join(children, ", "))
-		b.WriteString("\n")
-	}
-
+	b.WriteString(m.refreshStatus())
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render("Press any key to return"))
 
 	return b.String()
 }
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// Test PIDs are above the largest PID_MAX_LIMIT, so process.Alive never
+// finds them running.
+const (
+	pidA = 5000001 + iota
+	pidB
+	pidC
+)
+
+func newTestModel() Model {
+	m := New("test", Options{})
+	m.width = 100
+	m.height = 40
+	return m
+}
+
+func update(m Model, msg tea.Msg) Model {
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+// feed delivers procs as the result of the latest list fetch.
+func feed(m Model, procs ...process.Info) Model {
+	return update(m, processMsg{processes: procs, seq: m.fetchSeq})
+}
+
+func proc(pid int, name string, cpu float64) process.Info {
+	return process.Info{PID: pid, PPID: 1, Name: name, CPU: cpu}
+}
+
+func TestRestoreCursor(t *testing.T) {
+	a, b, c := proc(pidA, "a", 10), proc(pidB, "b", 5), proc(pidC, "c", 1)
+	reused := proc(pidB, "other", 50)
+	repeat := func(procs ...process.Info) [][]process.Info {
+		// Enough refreshes for ghost rows to expire.
+		return slices.Repeat([][]process.Info{procs}, churnRefreshes+1)
+	}
+
+	tests := []struct {
+		name     string
+		next     [][]process.Info
+		wantRow  process.Info // row under the cursor
+		wantGone string
+	}{
+		{"follows pid after re-sort", [][]process.Info{{a, proc(pidB, "b", 50), c}}, b, ""},
+		{"exited pid keeps ghost row", [][]process.Info{{a, c}}, b, "has exited"},
+		{"exited pid after ghost expired", repeat(a, c), c, "has exited"},
+		{"reused pid keeps ghost row", [][]process.Info{{a, reused, c}}, b, "has exited"},
+		{"reused pid after ghost expired", repeat(a, reused, c), c, "has exited"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := feed(newTestModel(), a, b, c)
+			m.cursor = 1
+			m.syncSelection()
+			if m.selectedPID != pidB {
+				t.Fatalf("selectedPID = %d, want %d", m.selectedPID, pidB)
+			}
+
+			for _, procs := range tt.next {
+				m = feed(m, procs...)
+			}
+			if got := m.filtered[m.cursor]; keyOf(got) != keyOf(tt.wantRow) {
+				t.Errorf("cursor on %d %s, want %d %s", got.PID, got.Name, tt.wantRow.PID, tt.wantRow.Name)
+			}
+			if m.selectedPID != pidB || m.selectedName != "b" {
+				t.Errorf("selected %d %s, want %d b", m.selectedPID, m.selectedName, pidB)
+			}
+			if m.selectedGone != tt.wantGone {
+				t.Errorf("selectedGone = %q, want %q", m.selectedGone, tt.wantGone)
+			}
+		})
+	}
+}
+
+func TestSyncSelection(t *testing.T) {
+	m := feed(newTestModel(), proc(pidA, "a", 10), proc(pidB, "b", 5))
+	tests := []struct {
+		cursor   int
+		wantPID  int
+		wantName string
+	}{
+		{0, pidA, "a"},
+		{1, pidB, "b"},
+		{2, 0, ""}, // past the end of the list
+	}
+	for _, tt := range tests {
+		m.cursor = tt.cursor
+		m.syncSelection()
+		if m.selectedPID != tt.wantPID || m.selectedName != tt.wantName {
+			t.Errorf("cursor %d: selected %d %q, want %d %q", tt.cursor, m.selectedPID, m.selectedName, tt.wantPID, tt.wantName)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// churnRefreshes is the number of refreshes for which new processes are
+// highlighted and exited ones are kept as ghost rows.
+const churnRefreshes = 3
+
+// procKey identifies a process across snapshots. The name is part of it so
+// that a reused PID counts as a new process.
+type procKey struct {
+	pid  int
+	name string
+}
+
+func keyOf(p process.Info) procKey {
+	return procKey{pid: p.PID, name: p.Name}
+}
+
+// ghost is the last known state of an exited process, shown for a few
+// refreshes.
+type ghost struct {
+	info process.Info
+	left int // refreshes left
+}
+
+// trackChurn diffs the new snapshot against m.processes, which must still
+// hold the previous one. Churn is tracked on the All tab only: the Top tab
+// lists just the busiest processes, so rows coming and going there are not
+// starts and exits. The ps and lsof runs of pstop itself are not counted.
+func (m *Model) trackChurn(procs []process.Info) {
+	if m.tab != TabAll {
+		return
+	}
+	if !m.churnReady {
+		// The first snapshot is the baseline.
+		m.churnReady = true
+		return
+	}
+
+	for k := range m.newProcs {
+		if m.newProcs[k]--; m.newProcs[k] == 0 {
+			delete(m.newProcs, k)
+		}
+	}
+	for k, g := range m.ghosts {
+		if g.left--; g.left == 0 {
+			delete(m.ghosts, k)
+		} else {
+			m.ghosts[k] = g
+		}
+	}
+
+	prev := make(map[procKey]bool, len(m.processes))
+	for _, p := range m.processes {
+		prev[keyOf(p)] = true
+	}
+	self := os.Getpid()
+	current := make(map[procKey]bool, len(procs))
+	m.spawned = 0
+	for _, p := range procs {
+		k := keyOf(p)
+		current[k] = true
+		delete(m.ghosts, k)
+		if !prev[k] && p.PPID != self {
+			m.newProcs[k] = churnRefreshes
+			m.spawned++
+		}
+	}
+	m.exited = 0
+	for _, p := range m.processes {
+		if k := keyOf(p); !current[k] && p.PPID != self {
+			m.ghosts[k] = ghost{info: p, left: churnRefreshes}
+			m.exited++
+		}
+	}
+}
+
+// resetChurn forgets the tracked churn, e.g. when switching tabs.
+func (m *Model) resetChurn() {
+	m.churnReady = false
+	m.newProcs = make(map[procKey]int)
+	m.ghosts = make(map[procKey]ghost)
+	m.spawned = 0
+	m.exited = 0
+}
+
+// isGhost reports whether p is the ghost row of an exited process.
+func (m Model) isGhost(p process.Info) bool {
+	_, ok := m.ghosts[keyOf(p)]
+	return ok
+}
+
+// withGhosts returns the listed processes followed by the ghost rows.
+func (m Model) withGhosts() []process.Info {
+	if len(m.ghosts) == 0 {
+		return m.processes
+	}
+	procs := make([]process.Info, 0, len(m.processes)+len(m.ghosts))
+	procs = append(procs, m.processes...)
+	for _, g := range m.ghosts {
+		procs = append(procs, g.info)
+	}
+	return procs
+}
+
+// churnStatus summarises the starts and exits of the last refresh.
+func (m Model) churnStatus() string {
+	if m.tab != TabAll || !m.churnReady {
+		return ""
+	}
+	return newStyle.Render(fmt.Sprintf("+%d started", m.spawned)) + dimStyle.Render(", ") +
+		exitedStyle.Render(fmt.Sprintf("-%d exited", m.exited))
+}
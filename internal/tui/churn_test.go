@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// names returns the sorted names of the processes in keys.
+func names[V any](keys map[procKey]V) []string {
+	var result []string
+	for k := range keys {
+		result = append(result, k.name)
+	}
+	slices.Sort(result)
+	return result
+}
+
+func TestTrackChurn(t *testing.T) {
+	a, b, c := proc(pidA, "a", 0), proc(pidB, "b", 0), proc(pidC, "c", 0)
+	// pstop's own ps and lsof runs are not churn.
+	child := proc(pidC+1, "ps", 0)
+	child.PPID = os.Getpid()
+
+	steps := []struct {
+		procs       []process.Info
+		wantSpawned int
+		wantExited  int
+		wantNew     []string
+		wantGhosts  []string
+	}{
+		{[]process.Info{a, b}, 0, 0, nil, nil}, // baseline
+		{[]process.Info{a, c, child}, 1, 1, []string{"c"}, []string{"b"}},
+		{[]process.Info{a, c}, 0, 0, []string{"c"}, []string{"b"}},
+		{[]process.Info{a, c}, 0, 0, []string{"c"}, []string{"b"}},
+		{[]process.Info{a, c}, 0, 0, nil, nil}, // expired after churnRefreshes
+		// A reused PID is an exit and a start.
+		{[]process.Info{proc(pidA, "a2", 0), c}, 1, 1, []string{"a2"}, []string{"a"}},
+		// A process that comes back before its ghost expires is not a ghost.
+		{[]process.Info{a, c}, 1, 1, []string{"a", "a2"}, []string{"a2"}},
+	}
+
+	m := newTestModel()
+	for i, s := range steps {
+		m.trackChurn(s.procs)
+		m.processes = s.procs
+		if m.spawned != s.wantSpawned || m.exited != s.wantExited {
+			t.Errorf("step %d: +%d -%d, want +%d -%d", i, m.spawned, m.exited, s.wantSpawned, s.wantExited)
+		}
+		if got := names(m.newProcs); !slices.Equal(got, s.wantNew) {
+			t.Errorf("step %d: new = %v, want %v", i, got, s.wantNew)
+		}
+		if got := names(m.ghosts); !slices.Equal(got, s.wantGhosts) {
+			t.Errorf("step %d: ghosts = %v, want %v", i, got, s.wantGhosts)
+		}
+	}
+}
+
+func TestTrackChurnTopTab(t *testing.T) {
+	m := newTestModel()
+	m.tab = TabTop
+	m.trackChurn([]process.Info{proc(pidA, "a", 0)})
+	m.processes = []process.Info{proc(pidA, "a", 0)}
+	m.trackChurn([]process.Info{proc(pidB, "b", 0)})
+	if m.churnReady || len(m.newProcs) != 0 || len(m.ghosts) != 0 {
+		t.Errorf("churn tracked on the Top tab: new %v, ghosts %v", names(m.newProcs), names(m.ghosts))
+	}
+	if m.churnStatus() != "" {
+		t.Errorf("churnStatus() = %q on the Top tab, want empty", m.churnStatus())
+	}
+}
+
+func TestGhostRows(t *testing.T) {
+	m := newTestModel()
+	m = feed(m, proc(pidA, "a", 1), proc(pidB, "b", 2))
+	m = feed(m, proc(pidA, "a", 1))
+
+	if len(m.filtered) != 2 || !m.isGhost(m.filtered[0]) || m.isGhost(m.filtered[1]) {
+		t.Fatalf("filtered = %+v, want ghost b then a", m.filtered)
+	}
+	if got := m.churnStatus(); !strings.Contains(got, "+0 started") || !strings.Contains(got, "-1 exited") {
+		t.Errorf("churnStatus() = %q, want +0 started, -1 exited", got)
+	}
+	for range churnRefreshes {
+		m = feed(m, proc(pidA, "a", 1))
+	}
+	if len(m.filtered) != 1 || m.filtered[0].PID != pidA {
+		t.Errorf("filtered = %+v, want the ghost row expired", m.filtered)
+	}
+}
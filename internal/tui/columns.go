@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/columns"
+)
+
+// sortIndicator is appended to the title of the sort column.
+func sortIndicator(desc bool) string {
+	if desc {
+		return " v"
+	}
+	return " ^"
+}
+
+// columnWidth is the width of c in the process table, leaving room for the
+// sort indicator after its title.
+func columnWidth(c columns.Column) int {
+	return max(c.Width, len(c.Title)+len(sortIndicator(true)))
+}
+
+// tableRow lays out one cell per visible column, truncating values that
+// are too wide. The last column is not padded.
+func (m Model) tableRow(cells []string) string {
+	var b strings.Builder
+	for i, c := range m.cols {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		width := columnWidth(c)
+		cell := truncate(cells[i], width)
+		switch {
+		case i == len(m.cols)-1:
+			b.WriteString(cell)
+		case c.Right:
+			fmt.Fprintf(&b, "%*s", width, cell)
+		default:
+			fmt.Fprintf(&b, "%-*s", width, cell)
+		}
+	}
+	return b.String()
+}
+
+// dataColumns returns the columns whose data is fetched: the visible ones
+// and the sort column, which may be hidden.
+func (m Model) dataColumns() []columns.Column {
+	cols := m.cols
+	if c, ok := columns.ByID(m.sortCol); ok {
+		cols = append(cols[:len(cols):len(cols)], c)
+	}
+	return cols
+}
+
+// setSort sorts by the column with the given ID in its default direction,
+// or reverses the order if it is already the sort column.
+func (m *Model) setSort(id string) {
+	if m.sortCol == id {
+		m.sortDesc = !m.sortDesc
+	} else {
+		c, _ := columns.ByID(id)
+		m.sortCol = id
+		m.sortDesc = c.Desc
+	}
+	m.applyFilter()
+}
+
+// moveSort moves the sort column by delta among the visible columns.
+func (m *Model) moveSort(delta int) {
+	i := 0
+	for j, c := range m.cols {
+		if c.ID == m.sortCol {
+			i = j + delta
+		}
+	}
+	i = (i + len(m.cols)) % len(m.cols)
+	m.setSort(m.cols[i].ID)
+}
+
+// openChooser shows the column chooser, listing the visible columns in
+// their order followed by the hidden ones.
+func (m *Model) openChooser() {
+	m.chooser = append([]columns.Column(nil), m.cols...)
+	m.chooserOn = make(map[string]bool, len(m.cols))
+	for _, c := range m.cols {
+		m.chooserOn[c.ID] = true
+	}
+	for _, c := range columns.All() {
+		if !m.chooserOn[c.ID] {
+			m.chooser = append(m.chooser, c)
+		}
+	}
+	m.chooserCursor = 0
+	m.choosingCols = true
+	m.statusMsg = ""
+}
+
+// applyChooser shows the columns checked in the chooser, in its order.
+func (m *Model) applyChooser() {
+	var cols []columns.Column
+	for _, c := range m.chooser {
+		if m.chooserOn[c.ID] {
+			cols = append(cols, c)
+		}
+	}
+	m.cols = cols
+}
+
+func (m Model) handleChooserKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	i := m.chooserCursor
+	switch msg.String() {
+	case "esc", "enter", "C", "q":
+		m.choosingCols = false
+		// Columns that were just added need data.
+		cmd := m.fetchList()
+		return m, cmd
+
+	case "up", "k":
+		m.chooserCursor = max(i-1, 0)
+
+	case "down", "j":
+		m.chooserCursor = min(i+1, len(m.chooser)-1)
+
+	case " ", "x":
+		id := m.chooser[i].ID
+		if m.chooserOn[id] && len(m.cols) == 1 {
+			m.statusMsg = "At least one column must be shown"
+			return m, nil
+		}
+		m.chooserOn[id] = !m.chooserOn[id]
+		m.applyChooser()
+
+	case "K", "shift+up":
+		if i > 0 {
+			m.chooser[i-1], m.chooser[i] = m.chooser[i], m.chooser[i-1]
+			m.chooserCursor--
+			m.applyChooser()
+		}
+
+	case "J", "shift+down":
+		if i < len(m.chooser)-1 {
+			m.chooser[i+1], m.chooser[i] = m.chooser[i], m.chooser[i+1]
+			m.chooserCursor++
+			m.applyChooser()
+		}
+	}
+	return m, nil
+}
+
+// renderChooser renders the column chooser.
+func (m Model) renderChooser() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Columns"))
+	b.WriteString("\n\n")
+	for i, c := range m.chooser {
+		check := "[ ]"
+		if m.chooserOn[c.ID] {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %-8s %s", check, c.Title, dimStyle.Render(c.ID))
+		if c.ID == m.sortCol {
+			line += dimStyle.Render("  (sort" + sortIndicator(m.sortDesc) + ")")
+		}
+		if i == m.chooserCursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.statusMsg != "" {
+		b.WriteString(statusStyle.Render(m.statusMsg))
+		b.WriteString("\n")
+	}
+	b.WriteString(dimStyle.Render("space show/hide • J/K move down/up • esc done"))
+	b.WriteString("\n")
+	return b.String()
+}
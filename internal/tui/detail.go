@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// detailSection is a sub-tab of the detail view.
+type detailSection int
+
+const (
+	sectionOverview detailSection = iota
+	sectionConnections
+	sectionFiles
+	sectionEnv
+	sectionChildren
+	numSections
+)
+
+func (s detailSection) String() string {
+	switch s {
+	case sectionConnections:
+		return "Connections"
+	case sectionFiles:
+		return "Open Files"
+	case sectionEnv:
+		return "Environment"
+	case sectionChildren:
+		return "Children"
+	default:
+		return "Overview"
+	}
+}
+
+// detailFrame is a detail view to return to after drilling into a child.
+type detailFrame struct {
+	info    *process.DetailedInfo
+	section detailSection
+	scroll  int
+	cursor  int
+}
+
+// openDetail shows info in the detail view. Opening a different process
+// while the view is already showing one drills down into it, remembering
+// the current process so that esc returns to it.
+func (m *Model) openDetail(info *process.DetailedInfo) {
+	if m.showDetail && m.detail != nil && m.detail.PID != info.PID {
+		m.detailStack = append(m.detailStack, detailFrame{
+			info:    m.detail,
+			section: m.detailSection,
+			scroll:  m.detailScroll,
+			cursor:  m.detailCursor,
+		})
+	}
+	m.detail = info
+	m.detailGone = false
+	m.detailNote = ""
+	m.detailSection = sectionOverview
+	m.detailScroll = 0
+	m.detailCursor = 0
+	m.showDetail = true
+}
+
+// closeDetail returns to the previous process of the back stack, or closes
+// the detail view when there is none.
+func (m *Model) closeDetail() {
+	m.detailNote = ""
+	if n := len(m.detailStack); n > 0 {
+		f := m.detailStack[n-1]
+		m.detailStack = m.detailStack[:n-1]
+		m.detail = f.info
+		m.detailGone = false
+		m.detailSection = f.section
+		m.detailScroll = f.scroll
+		m.detailCursor = f.cursor
+		return
+	}
+	m.showDetail = false
+}
+
+func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lines := len(m.detailLines())
+	page := m.detailHeight()
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Close), key.Matches(msg, m.keys.Back):
+		m.closeDetail()
+
+	case key.Matches(msg, m.keys.NextSection):
+		m.detailSection = (m.detailSection + 1) % numSections
+		m.detailScroll = 0
+		m.detailCursor = 0
+
+	case key.Matches(msg, m.keys.PrevSection):
+		m.detailSection = (m.detailSection + numSections - 1) % numSections
+		m.detailScroll = 0
+		m.detailCursor = 0
+
+	case key.Matches(msg, m.keys.Up):
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(-1, lines, page)
+		} else {
+			m.detailScroll = max(m.detailScroll-1, 0)
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(1, lines, page)
+		} else {
+			m.detailScroll = min(m.detailScroll+1, max(lines-page, 0))
+		}
+
+	case key.Matches(msg, m.keys.PageUp):
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(-page, lines, page)
+		} else {
+			m.detailScroll = max(m.detailScroll-page, 0)
+		}
+
+	case key.Matches(msg, m.keys.PageDown):
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(page, lines, page)
+		} else {
+			m.detailScroll = min(m.detailScroll+page, max(lines-page, 0))
+		}
+
+	case key.Matches(msg, m.keys.Open):
+		if m.detailSection == sectionChildren && m.detailCursor < len(m.detail.Children) {
+			m.detailNote = ""
+			return m, fetchDetail(m.detail.Children[m.detailCursor], false)
+		}
+	}
+	return m, nil
+}
+
+// moveDetailCursor moves the child selection by delta rows, scrolling to
+// keep it visible.
+func (m *Model) moveDetailCursor(delta, lines, page int) {
+	m.detailCursor = min(max(m.detailCursor+delta, 0), max(lines-1, 0))
+	if m.detailCursor < m.detailScroll {
+		m.detailScroll = m.detailCursor
+	}
+	if m.detailCursor >= m.detailScroll+page {
+		m.detailScroll = m.detailCursor - page + 1
+	}
+}
+
+// sectionLabels returns the labels of the section bar, with the number of
+// entries of each section but the overview.
+func (m Model) sectionLabels() []string {
+	d := m.detail
+	counts := map[detailSection]int{
+		sectionConnections: len(d.Connections),
+		sectionFiles:       len(d.Files),
+		sectionEnv:         len(d.EnvVars),
+		sectionChildren:    len(d.Children),
+	}
+	labels := make([]string, numSections)
+	for s := sectionOverview; s < numSections; s++ {
+		labels[s] = s.String()
+		if s != sectionOverview {
+			labels[s] = fmt.Sprintf("%s (%d)", labels[s], counts[s])
+		}
+	}
+	return labels
+}
+
+// detailHeight is the number of content rows visible in the detail view.
+func (m Model) detailHeight() int {
+	// Title(1) + tab bar(1) + detail title(1) + section bar(1) + blank
+	// lines(2) + footer(2).
+	overhead := 8 + strings.Count(m.renderHeader(), "\n")
+	if m.searching || m.filter != "" {
+		overhead++
+	}
+	if m.detailGone {
+		overhead++
+	}
+	if m.detailNote != "" {
+		overhead++
+	}
+	h := m.height - overhead
+	if h < 3 {
+		h = 10
+	}
+	return h
+}
+
+// detailLines returns the content of the current section, one row per
+// line. In the Children section row i is d.Children[i].
+func (m Model) detailLines() []string {
+	d := m.detail
+	width := max(m.width, 40)
+
+	switch m.detailSection {
+	case sectionConnections:
+		if len(d.Connections) == 0 {
+			return []string{dimStyle.Render("No network connections")}
+		}
+		lines := []string{headerStyle.Render(fmt.Sprintf("%-5s %-30s %-30s %s", "PROTO", "LOCAL", "REMOTE", "STATE"))}
+		for _, c := range d.Connections {
+			lines = append(lines, fmt.Sprintf("%-5s %-30s %-30s %s",
+				c.Protocol, truncate(c.LocalAddr, 30), truncate(c.RemoteAddr, 30), c.State))
+		}
+		return lines
+
+	case sectionFiles:
+		if len(d.Files) == 0 {
+			return []string{dimStyle.Render("No open files")}
+		}
+		lines := []string{headerStyle.Render(fmt.Sprintf("%-6s %-8s %s", "FD", "TYPE", "NAME"))}
+		for _, f := range d.Files {
+			lines = append(lines, fmt.Sprintf("%-6s %-8s %s", f.FD, truncate(f.Type, 8), truncate(f.Name, width-16)))
+		}
+		return lines
+
+	case sectionEnv:
+		if len(d.EnvVars) == 0 {
+			return []string{dimStyle.Render("No environment variables visible")}
+		}
+		keys := make([]string, 0, len(d.EnvVars))
+		for k := range d.EnvVars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		lines := make([]string, len(keys))
+		for i, k := range keys {
+			lines[i] = labelStyle.Render(k) + "=" + truncate(d.EnvVars[k], max(width-len(k)-1, 10))
+		}
+		return lines
+
+	case sectionChildren:
+		if len(d.Children) == 0 {
+			return nil
+		}
+		byPID := make(map[int]process.Info, len(m.processes))
+		for _, p := range m.processes {
+			byPID[p.PID] = p
+		}
+		lines := make([]string, len(d.Children))
+		for i, pid := range d.Children {
+			p, ok := byPID[pid]
+			line := fmt.Sprintf("%-8d %-20s %6.1f%% %6.1f%%", pid, truncate(p.Name, 20), p.CPU, p.Mem)
+			if !ok {
+				line = fmt.Sprintf("%-8d %s", pid, dimStyle.Render("(not in the process list)"))
+			}
+			if i == m.detailCursor {
+				line = selectedStyle.Render(line)
+			}
+			lines[i] = line
+		}
+		return lines
+	}
+
+	lines := []string{
+		labelStyle.Render("User:       ") + d.User,
+		labelStyle.Render("CPU:        ") + fmt.Sprintf("%.1f%%", d.CPU),
+		labelStyle.Render("Memory:     ") + fmt.Sprintf("%.1f%%", d.Mem),
+		labelStyle.Render("Open Files: ") + fmt.Sprintf("%d", d.OpenFiles),
+	}
+	if len(d.Ports) > 0 {
+		ports := make([]string, len(d.Ports))
+		for i, p := range d.Ports {
+			ports[i] = fmt.Sprintf("%d", p)
+		}
+		lines = append(lines, labelStyle.Render("Ports:      ")+strings.Join(ports, ", "))
+	}
+	lines = append(lines, labelStyle.Render("Children:   ")+fmt.Sprintf("%d", len(d.Children)))
+	lines = append(lines, "")
+	lines = append(lines, strings.Split(strings.TrimSuffix(m.renderHistory(d.PID), "\n"), "\n")...)
+	return lines
+}
+
+func (m Model) renderDetail() string {
+	var b strings.Builder
+
+	d := m.detail
+	title := fmt.Sprintf("Process Details: %s (PID %d)", d.Name, d.PID)
+	for i := len(m.detailStack) - 1; i >= 0; i-- {
+		title += fmt.Sprintf(" < %s", m.detailStack[i].info.Name)
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+
+	var parts []string
+	for i, name := range m.sectionLabels() {
+		if detailSection(i) == m.detailSection {
+			parts = append(parts, activeTabStyle.Render(fmt.Sprintf("[%s]", name)))
+		} else {
+			parts = append(parts, inactiveTabStyle.Render(fmt.Sprintf(" %s ", name)))
+		}
+	}
+	b.WriteString(strings.Join(parts, " "))
+	b.WriteString("\n\n")
+
+	lines := m.detailLines()
+	if m.detailSection == sectionChildren && len(lines) == 0 {
+		lines = []string{dimStyle.Render("No child processes")}
+	}
+	page := m.detailHeight()
+	start := min(m.detailScroll, max(len(lines)-page, 0))
+	end := min(start+page, len(lines))
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.detailGone {
+		b.WriteString(warnStyle.Render("Process has exited; showing its last known state"))
+		b.WriteString("\n")
+	}
+	if m.detailNote != "" {
+		b.WriteString(statusStyle.Render(m.detailNote))
+		b.WriteString("\n")
+	}
+	hint := "tab/shift+tab section • j/k scroll • esc back"
+	if m.detailSection == sectionChildren {
+		hint = "tab/shift+tab section • j/k select • enter open child • esc back"
+	}
+	if len(lines) > page {
+		hint = fmt.Sprintf("%d-%d of %d • %s", start+1, end, len(lines), hint)
+	}
+	b.WriteString(m.refreshStatus() + dimStyle.Render(" • "+hint))
+
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// coreMeterWidth is the width of one per-core meter including its label.
+	coreMeterWidth = 24
+	// maxCoreRows is the most rows of per-core meters shown before falling
+	// back to a single total CPU meter.
+	maxCoreRows = 4
+)
+
+// renderHeader renders the system summary panel: uptime, load, and counts,
+// followed by CPU meters and memory and swap bars.
+func (m Model) renderHeader() string {
+	if m.sys == nil || m.hideHeader {
+		return ""
+	}
+	s := m.sys
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("%s %s  %s %.2f %.2f %.2f  %s %d, %d threads\n",
+		labelStyle.Render("Up"), formatUptime(s.Uptime()),
+		labelStyle.Render("Load"), s.Load[0], s.Load[1], s.Load[2],
+		labelStyle.Render("Tasks"), s.Processes, s.Threads))
+
+	cols := max((m.width+2)/(coreMeterWidth+2), 1)
+	rows := (len(s.Cores) + cols - 1) / cols
+	if len(s.Cores) > 1 && rows <= maxCoreRows {
+		for r := 0; r < rows; r++ {
+			var parts []string
+			for c := 0; c < cols; c++ {
+				i := r*cols + c
+				if i >= len(s.Cores) {
+					break
+				}
+				parts = append(parts, meter(fmt.Sprintf("%-3d", i), s.Cores[i], coreMeterWidth-11,
+					fmt.Sprintf("%3.0f%%", s.Cores[i])))
+			}
+			b.WriteString(strings.Join(parts, "  "))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(meter("CPU", s.CPU, m.barWidth(), fmt.Sprintf("%5.1f%%", s.CPU)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(meter("Mem", s.MemPercent(), m.barWidth(),
+		fmt.Sprintf("%s/%s", formatMem(s.MemUsed), formatMem(s.MemTotal))))
+	b.WriteString("\n")
+	if s.SwapTotal > 0 {
+		b.WriteString(meter("Swp", s.SwapPercent(), m.barWidth(),
+			fmt.Sprintf("%s/%s", formatMem(s.SwapUsed), formatMem(s.SwapTotal))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// barWidth is the width of the full-width CPU, memory, and swap bars.
+func (m Model) barWidth() int {
+	return min(max(m.width-24, 10), 60)
+}
+
+// meter renders "label [|||||     ] value" with the bar coloured by pct.
+func meter(label string, pct float64, width int, value string) string {
+	pct = min(max(pct, 0), 100)
+	filled := int(pct / 100 * float64(width))
+	bar := strings.Repeat("|", filled)
+	switch {
+	case pct > 80:
+		bar = highCPUStyle.Render(bar)
+	case pct > 50:
+		bar = medCPUStyle.Render(bar)
+	default:
+		bar = meterStyle.Render(bar)
+	}
+	return fmt.Sprintf("%s [%s%s] %s", labelStyle.Render(label), bar, strings.Repeat(" ", width-filled), value)
+}
+
+// formatMem formats a size in KB using the largest fitting unit.
+func formatMem(kb int64) string {
+	switch {
+	case kb >= 1024*1024:
+		return fmt.Sprintf("%.1fG", float64(kb)/(1024*1024))
+	case kb >= 1024:
+		return fmt.Sprintf("%.1fM", float64(kb)/1024)
+	default:
+		return fmt.Sprintf("%dK", kb)
+	}
+}
+
+// formatUptime formats a duration as days, hours, and minutes.
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	mins := int(d % time.Hour / time.Minute)
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, mins)
+	}
+	return fmt.Sprintf("%dh %dm", hours, mins)
+}
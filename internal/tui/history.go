@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+const (
+	// historySize is the number of samples kept per process.
+	historySize = 120
+	// graphHeight is the number of rows of a history graph.
+	graphHeight = 4
+)
+
+// blockChars draws the top of a graph column in eighths of a row.
+var blockChars = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// sample is one observation of a process.
+type sample struct {
+	at  time.Time
+	cpu float64
+	rss int64 // KB
+}
+
+// history is a ring buffer of the most recent samples of one process.
+type history struct {
+	name    string
+	samples [historySize]sample
+	start   int
+	n       int
+}
+
+func (h *history) add(s sample) {
+	if h.n < historySize {
+		h.samples[(h.start+h.n)%historySize] = s
+		h.n++
+		return
+	}
+	h.samples[h.start] = s
+	h.start = (h.start + 1) % historySize
+}
+
+// values returns f applied to each sample, oldest first.
+func (h *history) values(f func(sample) float64) []float64 {
+	vals := make([]float64, h.n)
+	for i := range vals {
+		vals[i] = f(h.samples[(h.start+i)%historySize])
+	}
+	return vals
+}
+
+// span returns the time between the oldest and newest sample.
+func (h *history) span() time.Duration {
+	if h.n < 2 {
+		return 0
+	}
+	first := h.samples[h.start]
+	last := h.samples[(h.start+h.n-1)%historySize]
+	return last.at.Sub(first.at)
+}
+
+// recordHistory adds a sample for each process and drops the history of
+// processes that are no longer listed, except the one shown in the detail
+// view. A PID reused by a different program starts a new history.
+func (m *Model) recordHistory(procs []process.Info, at time.Time) {
+	seen := make(map[int]bool, len(procs))
+	for _, p := range procs {
+		seen[p.PID] = true
+		h, ok := m.history[p.PID]
+		if !ok || h.name != p.Name {
+			h = &history{name: p.Name}
+			m.history[p.PID] = h
+		}
+		h.add(sample{at: at, cpu: p.CPU, rss: p.RSS})
+	}
+	for pid := range m.history {
+		if !seen[pid] && !(m.showDetail && m.detail != nil && m.detail.PID == pid) {
+			delete(m.history, pid)
+		}
+	}
+}
+
+// renderHistory renders CPU and RSS graphs of a process with min, average,
+// and max over the recorded window.
+func (m Model) renderHistory(pid int) string {
+	h := m.history[pid]
+	if h == nil || h.n == 0 {
+		return dimStyle.Render("No history recorded yet") + "\n"
+	}
+
+	width := min(max(m.width-12, 10), historySize)
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("History (%d samples over %s)", h.n, h.span().Round(time.Second))))
+	b.WriteString("\n")
+
+	cpu := h.values(func(s sample) float64 { return s.cpu })
+	lo, avg, hi := summarize(cpu)
+	b.WriteString(fmt.Sprintf("CPU  min %.1f%%  avg %.1f%%  max %.1f%%\n", lo, avg, hi))
+	b.WriteString(graph(cpu, width, graphHeight, func(v float64) string { return fmt.Sprintf("%.0f%%", v) }))
+
+	rss := h.values(func(s sample) float64 { return float64(s.rss) })
+	lo, avg, hi = summarize(rss)
+	b.WriteString(fmt.Sprintf("RSS  min %s  avg %s  max %s\n",
+		formatMem(int64(lo)), formatMem(int64(avg)), formatMem(int64(hi))))
+	b.WriteString(graph(rss, width, graphHeight, func(v float64) string { return formatMem(int64(v)) }))
+	return b.String()
+}
+
+// summarize returns the minimum, mean, and maximum of values.
+func summarize(values []float64) (lo, avg, hi float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	lo, hi = values[0], values[0]
+	var sum float64
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+		sum += v
+	}
+	return lo, sum / float64(len(values)), hi
+}
+
+// graph renders values as a block graph height rows tall, scaled from zero
+// to the largest value, which labels the top row. Series longer than width
+// are downsampled by taking the maximum of each bucket, so short spikes stay
+// visible.
+func graph(values []float64, width, height int, label func(float64) string) string {
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			lo := i * len(values) / width
+			hi := (i + 1) * len(values) / width
+			_, _, buckets[i] = summarize(values[lo:hi])
+		}
+		values = buckets
+	}
+	_, _, hi := summarize(values)
+
+	// Height of each column in eighths of a row.
+	levels := make([]int, len(values))
+	for i, v := range values {
+		if hi > 0 {
+			levels[i] = int(v / hi * float64(height*8))
+		}
+	}
+
+	var b strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		axis := ""
+		switch row {
+		case height - 1:
+			axis = label(hi)
+		case 0:
+			axis = label(0)
+		}
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%7s ", axis)))
+		var line strings.Builder
+		for _, l := range levels {
+			fill := min(max(l-row*8, 0), 8)
+			line.WriteRune(blockChars[fill])
+		}
+		b.WriteString(meterStyle.Render(line.String()))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
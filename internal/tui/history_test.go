@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestHistoryRingBuffer(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cpu := func(s sample) float64 { return s.cpu }
+
+	tests := []struct {
+		added    int
+		wantN    int
+		wantHead float64 // oldest CPU value kept
+		wantSpan time.Duration
+	}{
+		{0, 0, 0, 0},
+		{1, 1, 0, 0},
+		{3, 3, 0, 2 * time.Second},
+		{historySize, historySize, 0, (historySize - 1) * time.Second},
+		{historySize + 5, historySize, 5, (historySize - 1) * time.Second},
+		{3*historySize + 7, historySize, 2*historySize + 7, (historySize - 1) * time.Second},
+	}
+	for _, tt := range tests {
+		var h history
+		for i := range tt.added {
+			h.add(sample{at: start.Add(time.Duration(i) * time.Second), cpu: float64(i)})
+		}
+		vals := h.values(cpu)
+		if h.n != tt.wantN || len(vals) != tt.wantN {
+			t.Errorf("%d added: n = %d, %d values, want %d", tt.added, h.n, len(vals), tt.wantN)
+			continue
+		}
+		for i, v := range vals {
+			if want := tt.wantHead + float64(i); v != want {
+				t.Errorf("%d added: values[%d] = %v, want %v", tt.added, i, v, want)
+				break
+			}
+		}
+		if got := h.span(); got != tt.wantSpan {
+			t.Errorf("%d added: span() = %v, want %v", tt.added, got, tt.wantSpan)
+		}
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newTestModel()
+	m.recordHistory([]process.Info{proc(pidA, "a", 1), proc(pidB, "b", 2)}, at)
+	m.recordHistory([]process.Info{proc(pidA, "a", 3), proc(pidB, "b", 4)}, at.Add(time.Second))
+
+	// PID B is reused by another program and PID A exits.
+	m.recordHistory([]process.Info{proc(pidB, "other", 5)}, at.Add(2*time.Second))
+	if _, ok := m.history[pidA]; ok {
+		t.Error("history of exited PID A was kept")
+	}
+	if h := m.history[pidB]; h == nil || h.name != "other" || h.n != 1 {
+		t.Error("reused PID B did not start a new history")
+	}
+
+	// The process in the detail view keeps its history after it exits.
+	m.showDetail = true
+	m.detail = &process.DetailedInfo{PID: pidB, Name: "other"}
+	m.recordHistory(nil, at.Add(3*time.Second))
+	if h := m.history[pidB]; h == nil || h.n != 1 {
+		t.Error("history of PID B in the detail view was not kept")
+	}
+}
+
+func TestGraph(t *testing.T) {
+	label := func(v float64) string { return strings.Repeat("#", int(v)) }
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		height int
+		want   []string
+	}{
+		{"empty", nil, 10, 1, []string{"        "}},
+		{"all zero", []float64{0, 0}, 10, 1, []string{"          "}},
+		{"scaled to max", []float64{0, 1, 2}, 10, 2, []string{
+			"     ## " + "  █",
+			"        " + " ██",
+		}},
+		{"eighths", []float64{1, 2, 4, 8}, 10, 1, []string{
+			"######## " + "▁▂▄█",
+		}},
+		{"downsampled keeps spikes", []float64{1, 0, 0, 4, 2, 2}, 3, 1, []string{
+			"   #### " + "▂█▄",
+		}},
+	}
+	for _, tt := range tests {
+		got := strings.Split(strings.TrimSuffix(graph(tt.values, tt.width, tt.height, label), "\n"), "\n")
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: graph() =\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
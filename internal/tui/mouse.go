@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// wheelStep is the number of rows the scroll wheel moves.
+const wheelStep = 3
+
+// barIndex returns the index of the label at column x of a tab bar as
+// rendered by View and renderDetail: each label is bracketed or padded by
+// one character on either side, and labels are separated by a space. It
+// returns -1 if x is not on a label.
+func barIndex(labels []string, x int) int {
+	start := 0
+	for i, l := range labels {
+		end := start + len(l) + 2
+		if x >= start && x < end {
+			return i
+		}
+		start = end + 1
+	}
+	return -1
+}
+
+// tabBarRow is the screen row of the tab bar.
+func (m Model) tabBarRow() int {
+	return 1 + strings.Count(m.renderHeader(), "\n")
+}
+
+// contentRow is the screen row below the tab bar and the search bar where
+// the table or the detail view starts.
+func (m Model) contentRow() int {
+	row := m.tabBarRow() + 1
+	if m.searching || m.filter != "" {
+		row++
+	}
+	return row
+}
+
+// columnAt returns the index of the visible column at screen column x of
+// the process table, or -1.
+func (m Model) columnAt(x int) int {
+	start := 2 // mark column
+	for i, c := range m.cols {
+		if x < start {
+			return -1
+		}
+		end := start + columnWidth(c)
+		if x < end || i == len(m.cols)-1 {
+			return i
+		}
+		start = end + 1
+	}
+	return -1
+}
+
+// moveCursor moves the table cursor by delta rows.
+func (m *Model) moveCursor(delta int) {
+	if m.listLen() == 0 {
+		return
+	}
+	m.cursor += delta
+	m.clampCursor()
+	m.syncSelection()
+}
+
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || m.searching || m.confirming ||
+		m.pickingSignal || m.choosingCols || m.showHelp {
+		return m, nil
+	}
+	if m.showDetail && m.detail != nil {
+		return m.handleDetailMouse(msg)
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if m.tab != TabDev {
+			m.moveCursor(-wheelStep)
+		}
+		return m, nil
+
+	case tea.MouseButtonWheelDown:
+		if m.tab != TabDev {
+			m.moveCursor(wheelStep)
+		}
+		return m, nil
+	}
+	if msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if msg.Y == m.tabBarRow() {
+		if i := barIndex(tabNames, msg.X); i >= 0 && Tab(i) != m.tab {
+			return m.switchTab(Tab(i))
+		}
+		return m, nil
+	}
+	if m.tab == TabDev {
+		return m, nil
+	}
+
+	headerRow := m.contentRow()
+	switch {
+	case msg.Y == headerRow:
+		if i := m.columnAt(msg.X); i >= 0 {
+			m.setSort(m.cols[i].ID)
+		}
+	case msg.Y > headerRow && msg.Y <= headerRow+m.tableHeight():
+		if row := m.offset + msg.Y - headerRow - 1; row < len(m.filtered) {
+			m.cursor = row
+			m.clampCursor()
+			m.syncSelection()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleDetailMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	lines := len(m.detailLines())
+	page := m.detailHeight()
+	// Detail title, then the section bar and a blank line.
+	sectionRow := m.contentRow() + 1
+	firstLine := sectionRow + 2
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(-wheelStep, lines, page)
+		} else {
+			m.detailScroll = max(m.detailScroll-wheelStep, 0)
+		}
+
+	case tea.MouseButtonWheelDown:
+		if m.detailSection == sectionChildren {
+			m.moveDetailCursor(wheelStep, lines, page)
+		} else {
+			m.detailScroll = min(m.detailScroll+wheelStep, max(lines-page, 0))
+		}
+
+	case tea.MouseButtonLeft:
+		switch {
+		case msg.Y == sectionRow:
+			if i := barIndex(m.sectionLabels(), msg.X); i >= 0 {
+				m.detailSection = detailSection(i)
+				m.detailScroll = 0
+				m.detailCursor = 0
+			}
+		case m.detailSection == sectionChildren && msg.Y >= firstLine && msg.Y < firstLine+page:
+			// Selects the clicked child; enter opens it.
+			start := min(m.detailScroll, max(lines-page, 0))
+			if row := start + msg.Y - firstLine; row < lines {
+				m.detailCursor = row
+			}
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestBarIndex(t *testing.T) {
+	// Rendered as "[All]  Top   Dev ".
+	tests := []struct {
+		x    int
+		want int
+	}{
+		{-1, -1},
+		{0, 0},
+		{4, 0},
+		{5, -1},
+		{6, 1},
+		{10, 1},
+		{11, -1},
+		{12, 2},
+		{16, 2},
+		{17, -1},
+	}
+	for _, tt := range tests {
+		if got := barIndex(tabNames, tt.x); got != tt.want {
+			t.Errorf("barIndex(%d) = %d, want %d", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestColumnAt(t *testing.T) {
+	// The default columns start after the two-character mark column:
+	// PID at 2-9, NAME at 11-30, USER at 32-41, ..., COMMAND last.
+	tests := []struct {
+		x    int
+		want int
+	}{
+		{0, -1},
+		{1, -1},
+		{2, 0},
+		{9, 0},
+		{10, -1},
+		{11, 1},
+		{30, 1},
+		{31, -1},
+		{32, 2},
+		{500, 6}, // the last column runs to the edge
+	}
+	m := newTestModel()
+	for _, tt := range tests {
+		if got := m.columnAt(tt.x); got != tt.want {
+			t.Errorf("columnAt(%d) = %d, want %d", tt.x, got, tt.want)
+		}
+	}
+}
+
+// screenRow returns the first row of the rendered view that contains s.
+func screenRow(t *testing.T, m Model, s string) int {
+	t.Helper()
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, s) {
+			return i
+		}
+	}
+	t.Fatalf("%q not found in view:\n%s", s, m.View())
+	return -1
+}
+
+func click(m Model, x, y int) Model {
+	return update(m, tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+func TestHandleMouse(t *testing.T) {
+	sys := &process.SysStats{Cores: []float64{10, 20}, MemTotal: 1 << 20, MemUsed: 1 << 19}
+	tests := []struct {
+		name   string
+		sys    *process.SysStats
+		filter string
+	}{
+		{"no header", nil, ""},
+		{"header", sys, ""},
+		{"header and filter", sys, "500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.sys = tt.sys
+			m = feed(m, proc(pidA, "a", 30), proc(pidB, "b", 20), proc(pidC, "c", 10))
+			m.filter = tt.filter
+			m.applyFilter()
+
+			tabRow := screenRow(t, m, "[All]")
+			if tabRow != m.tabBarRow() {
+				t.Errorf("tabBarRow() = %d, want %d", m.tabBarRow(), tabRow)
+			}
+			headerRow := screenRow(t, m, "COMMAND")
+			if headerRow != m.contentRow() {
+				t.Errorf("contentRow() = %d, want %d", m.contentRow(), headerRow)
+			}
+
+			// Rows are hit where they are drawn.
+			got := click(m, 20, screenRow(t, m, strconv.Itoa(pidC)))
+			if got.cursor != 2 || got.selectedPID != pidC {
+				t.Errorf("row click: cursor %d on PID %d, want 2 on %d", got.cursor, got.selectedPID, pidC)
+			}
+			got = click(got, 20, screenRow(t, m, strconv.Itoa(pidA)))
+			if got.cursor != 0 || got.selectedPID != pidA {
+				t.Errorf("row click: cursor %d on PID %d, want 0 on %d", got.cursor, got.selectedPID, pidA)
+			}
+
+			// The header sorts, by NAME here.
+			if got := click(m, 12, headerRow); got.sortCol != "name" {
+				t.Errorf("header click: sort column %q, want name", got.sortCol)
+			}
+			// Clicking the sort column again reverses the order.
+			if got := click(m, 45, headerRow); got.sortCol != "cpu" || got.sortDesc {
+				t.Errorf("header click: sort %q desc %v, want cpu ascending", got.sortCol, got.sortDesc)
+			}
+
+			if got := click(m, 7, tabRow); got.tab != TabTop {
+				t.Errorf("tab click: tab %d, want Top", got.tab)
+			}
+			// The row above the tab bar is the title or the system header.
+			if got := click(m, 7, tabRow-1); got.tab != TabAll || got.cursor != 0 || got.sortCol != "cpu" {
+				t.Errorf("click above the tab bar changed the view: tab %d, cursor %d, sort %q",
+					got.tab, got.cursor, got.sortCol)
+			}
+		})
+	}
+}
+
+func TestHandleMouseWheel(t *testing.T) {
+	var procs []process.Info
+	for i := range 10 {
+		procs = append(procs, proc(pidA+i, "p", float64(10-i)))
+	}
+	m := feed(newTestModel(), procs...)
+
+	wheel := func(m Model, b tea.MouseButton) Model {
+		return update(m, tea.MouseMsg{Action: tea.MouseActionPress, Button: b})
+	}
+	m = wheel(m, tea.MouseButtonWheelDown)
+	if m.cursor != wheelStep || m.selectedPID != pidA+wheelStep {
+		t.Errorf("wheel down: cursor %d on PID %d, want %d", m.cursor, m.selectedPID, wheelStep)
+	}
+	m = wheel(m, tea.MouseButtonWheelUp)
+	m = wheel(m, tea.MouseButtonWheelUp)
+	if m.cursor != 0 || m.selectedPID != pidA {
+		t.Errorf("wheel up: cursor %d on PID %d, want 0", m.cursor, m.selectedPID)
+	}
+}
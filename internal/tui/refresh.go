@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultInterval is the refresh interval when none is configured.
+const defaultInterval = 2 * time.Second
+
+// intervalSteps are the refresh intervals offered by the faster and slower
+// keys.
+var intervalSteps = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	3 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// tickMsg triggers a refresh. gen is the tick generation it belongs to:
+// changing the interval or freezing starts a new generation, so that ticks
+// of the old one are dropped instead of running a second refresh loop.
+type tickMsg struct {
+	gen int
+}
+
+func tickCmd(interval time.Duration, gen int) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return tickMsg{gen: gen}
+	})
+}
+
+// fasterInterval returns the next shorter step than d, or d if there is
+// none.
+func fasterInterval(d time.Duration) time.Duration {
+	for i := len(intervalSteps) - 1; i >= 0; i-- {
+		if intervalSteps[i] < d {
+			return intervalSteps[i]
+		}
+	}
+	return d
+}
+
+// slowerInterval returns the next longer step than d, or d if there is
+// none.
+func slowerInterval(d time.Duration) time.Duration {
+	for _, s := range intervalSteps {
+		if s > d {
+			return s
+		}
+	}
+	return d
+}
+
+// formatInterval formats a refresh interval, e.g. "500ms", "2s", or "1m".
+func formatInterval(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%gs", d.Seconds())
+	}
+}
+
+// listCmd fetches the list of the current tab as fetch m.fetchSeq.
+func (m Model) listCmd() tea.Cmd {
+	if m.tab == TabDev {
+		return fetchDevGroups(m.fetchSeq)
+	}
+	return fetchProcesses(m.tab, m.dataColumns(), m.fetchSeq)
+}
+
+// fetchList starts a new fetch of the current tab's list. Results of earlier
+// fetches that are still in flight are dropped when they arrive, so that an
+// old snapshot never replaces a newer one.
+func (m *Model) fetchList() tea.Cmd {
+	m.fetchSeq++
+	m.fetching = true
+	return m.listCmd()
+}
+
+// refresh fetches everything the current view shows.
+func (m *Model) refresh() tea.Cmd {
+	cmds := []tea.Cmd{fetchSysStats(m.sampler), m.fetchList()}
+	if m.showDetail && m.detail != nil && !m.detailGone {
+		cmds = append(cmds, fetchDetail(m.detail.PID, true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// setInterval changes the refresh interval and restarts the ticks with it.
+func (m *Model) setInterval(d time.Duration) tea.Cmd {
+	if d == m.interval {
+		return nil
+	}
+	m.interval = d
+	m.statusMsg = "Refresh interval: " + formatInterval(d)
+	if m.frozen {
+		return nil
+	}
+	m.tickGen++
+	return tickCmd(m.interval, m.tickGen)
+}
+
+// toggleFreeze stops or resumes the periodic refresh. While frozen the last
+// snapshot stays on screen; resuming refreshes at once.
+func (m *Model) toggleFreeze() tea.Cmd {
+	m.frozen = !m.frozen
+	m.tickGen++
+	if m.frozen {
+		// Drop the result of a fetch in flight so the snapshot stays put.
+		m.fetchSeq++
+		m.fetching = false
+		return nil
+	}
+	return tea.Batch(m.refresh(), tickCmd(m.interval, m.tickGen))
+}
+
+// refreshStatus describes the refresh state for the status bar.
+func (m Model) refreshStatus() string {
+	if m.frozen {
+		return warnStyle.Render("PAUSED") + dimStyle.Render(" (z resume, r refresh)")
+	}
+	return dimStyle.Render("every " + formatInterval(m.interval))
+}
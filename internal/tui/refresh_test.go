@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestFasterSlowerInterval(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		faster time.Duration
+		slower time.Duration
+	}{
+		{2 * time.Second, time.Second, 3 * time.Second},
+		{250 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond},
+		{time.Minute, 30 * time.Second, time.Minute},
+		// Intervals between the steps move to the neighbouring step.
+		{1500 * time.Millisecond, time.Second, 2 * time.Second},
+		{100 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond},
+		{2 * time.Minute, time.Minute, 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := fasterInterval(tt.d); got != tt.faster {
+			t.Errorf("fasterInterval(%v) = %v, want %v", tt.d, got, tt.faster)
+		}
+		if got := slowerInterval(tt.d); got != tt.slower {
+			t.Errorf("slowerInterval(%v) = %v, want %v", tt.d, got, tt.slower)
+		}
+	}
+}
+
+func TestFormatInterval(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{250 * time.Millisecond, "250ms"},
+		{time.Second, "1s"},
+		{1500 * time.Millisecond, "1.5s"},
+		{30 * time.Second, "30s"},
+		{time.Minute, "1m"},
+		{2 * time.Minute, "2m"},
+		{90 * time.Second, "90s"},
+	}
+	for _, tt := range tests {
+		if got := formatInterval(tt.d); got != tt.want {
+			t.Errorf("formatInterval(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestTickGeneration(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(m *Model)
+		gen       int
+		wantFetch bool
+		wantCmd   bool
+	}{
+		{"current tick refreshes", nil, 0, true, true},
+		{"stale tick is dropped", func(m *Model) { m.setInterval(time.Second) }, 0, false, false},
+		{"tick of new interval refreshes", func(m *Model) { m.setInterval(time.Second) }, 1, true, true},
+		{"frozen tick is dropped", func(m *Model) { m.toggleFreeze() }, 1, false, false},
+		{"tick during slow fetch is skipped", func(m *Model) { m.fetchList() }, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.fetching = false
+			if tt.setup != nil {
+				tt.setup(&m)
+			}
+			seq := m.fetchSeq
+			next, cmd := m.Update(tickMsg{gen: tt.gen})
+			m = next.(Model)
+			if fetched := m.fetchSeq != seq; fetched != tt.wantFetch {
+				t.Errorf("fetched = %v, want %v", fetched, tt.wantFetch)
+			}
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("returned a command: %v, want %v", cmd != nil, tt.wantCmd)
+			}
+		})
+	}
+}
+
+func TestSetInterval(t *testing.T) {
+	m := newTestModel()
+	if cmd := m.setInterval(defaultInterval); cmd != nil || m.tickGen != 0 {
+		t.Errorf("unchanged interval started generation %d", m.tickGen)
+	}
+	if cmd := m.setInterval(time.Second); cmd == nil || m.tickGen != 1 {
+		t.Errorf("new interval: generation %d, want 1 with a tick", m.tickGen)
+	}
+	if m.statusMsg != "Refresh interval: 1s" {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+
+	// While frozen the interval is only recorded; resuming starts the ticks.
+	m.toggleFreeze()
+	gen := m.tickGen
+	if cmd := m.setInterval(5 * time.Second); cmd != nil || m.tickGen != gen || m.interval != 5*time.Second {
+		t.Errorf("frozen: interval %v, generation %d, want 5s and %d without a tick", m.interval, m.tickGen, gen)
+	}
+}
+
+func TestToggleFreezeDropsFetchInFlight(t *testing.T) {
+	m := newTestModel()
+	m = feed(m, proc(pidA, "a", 1))
+	m.fetchList()
+	inFlight := m.fetchSeq
+	m.toggleFreeze()
+
+	m = update(m, processMsg{processes: []process.Info{proc(pidB, "b", 1)}, seq: inFlight})
+	if len(m.processes) != 1 || m.processes[0].PID != pidA {
+		t.Errorf("processes = %+v, want the frozen snapshot", m.processes)
+	}
+	if m.fetching {
+		t.Error("still fetching after freeze")
+	}
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+// signals are the signals offered by the signal picker.
+var signals = process.Signals()
+
+// sendAction returns the action that sends sig.
+func sendAction(sig syscall.Signal) signalAction {
+	name := process.SignalName(sig)
+	return signalAction{sig: sig, verb: "send " + name + " to", done: "Sent " + name + " to"}
+}
+
+func newSignalInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Filter signals..."
+	ti.CharLimit = 32
+	return ti
+}
+
+// filteredSignals returns the signals whose name or description contains
+// the picker's filter, ignoring case and the SIG prefix.
+func (m Model) filteredSignals() []process.Signal {
+	query := strings.ToLower(strings.TrimSpace(m.signalInput.Value()))
+	if query == "" {
+		return signals
+	}
+	var result []process.Signal
+	for _, s := range signals {
+		name := strings.ToLower(s.Name)
+		if strings.Contains(name, query) || strings.Contains(strings.TrimPrefix(name, "sig"), query) ||
+			strings.Contains(strings.ToLower(s.Desc), query) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// openSignalPicker shows the signal picker with the last used signal
+// selected.
+func (m Model) openSignalPicker() (tea.Model, tea.Cmd) {
+	if m.tab == TabDev {
+		return m, nil
+	}
+	if _, err := m.actionTargets(); err != nil {
+		m.statusMsg = fmt.Sprintf("No signal sent: %v", err)
+		return m, nil
+	}
+	m.pickingSignal = true
+	m.signalInput.SetValue("")
+	m.signalCursor = 0
+	for i, s := range signals {
+		if s.Sig == m.lastSignal {
+			m.signalCursor = i
+		}
+	}
+	m.signalInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) handleSignalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.pickingSignal = false
+		m.signalInput.Blur()
+		return m, nil
+
+	case "enter":
+		list := m.filteredSignals()
+		m.pickingSignal = false
+		m.signalInput.Blur()
+		if m.signalCursor >= len(list) {
+			return m, nil
+		}
+		m.lastSignal = list[m.signalCursor].Sig
+		return m.requestSignal(sendAction(m.lastSignal))
+
+	case "up", "ctrl+p":
+		m.signalCursor = max(m.signalCursor-1, 0)
+		return m, nil
+
+	case "down", "ctrl+n":
+		m.signalCursor = min(m.signalCursor+1, max(len(m.filteredSignals())-1, 0))
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	prev := m.signalInput.Value()
+	m.signalInput, cmd = m.signalInput.Update(msg)
+	if m.signalInput.Value() != prev {
+		m.signalCursor = 0
+	}
+	return m, cmd
+}
+
+// renderSignalPicker renders the signal picker with its targets, filter,
+// and the matching signals.
+func (m Model) renderSignalPicker() string {
+	var b strings.Builder
+
+	targets, _ := m.actionTargets()
+	title := "Send signal"
+	switch {
+	case len(targets) == 1:
+		title = fmt.Sprintf("Send signal to %s (PID %d)", targets[0].name, targets[0].pid)
+	case len(targets) > 1:
+		title = fmt.Sprintf("Send signal to %d marked processes", len(targets))
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(m.signalInput.View())
+	b.WriteString("\n\n")
+
+	list := m.filteredSignals()
+	if len(list) == 0 {
+		b.WriteString(dimStyle.Render("No matching signals"))
+		b.WriteString("\n")
+	}
+	for i, s := range list {
+		line := fmt.Sprintf("%-9s %2d  %s", s.Name, int(s.Sig), s.Desc)
+		if s.Sig == m.lastSignal {
+			line += dimStyle.Render("  (last used)")
+		}
+		if i == m.signalCursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("type to filter • up/down select • enter send • esc cancel"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lu-zhengda/pstop/internal/process"
+)
+
+func TestFilteredSignals(t *testing.T) {
+	var all []string
+	for _, s := range signals {
+		all = append(all, s.Name)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", all},
+		{"   ", all},
+		{"sigkill", []string{"SIGKILL"}},
+		{"USR", []string{"SIGUSR1", "SIGUSR2"}},
+		{" stop ", []string{"SIGSTOP", "SIGTSTP"}}, // name, then description
+		{"kill", []string{"SIGTERM", "SIGKILL"}},   // "default for kill"
+		{"hang", []string{"SIGHUP", "SIGWINCH"}},   // "Hang up", "changed"
+		{"sigcont", []string{"SIGCONT"}},
+		{"bogus", nil},
+	}
+	for _, tt := range tests {
+		m := newTestModel()
+		m.signalInput.SetValue(tt.filter)
+		var got []string
+		for _, s := range m.filteredSignals() {
+			got = append(got, s.Name)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("filteredSignals(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestSendAction(t *testing.T) {
+	for _, s := range process.Signals() {
+		a := sendAction(s.Sig)
+		if a.sig != s.Sig || a.verb != "send "+s.Name+" to" || a.done != "Sent "+s.Name+" to" {
+			t.Errorf("sendAction(%s) = %+v", s.Name, a)
+		}
+	}
+}
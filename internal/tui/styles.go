@@ -15,6 +15,9 @@ var (
 	highCPUStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))  // Red for CPU > 50%
 	medCPUStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11")) // Yellow for CPU 20-50%
 	selectedStyle = lipgloss.NewStyle().Background(lipgloss.Color("8"))  // Highlighted row
+	markedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("13")) // Marked for a batch action
+	newStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("10")) // Recently started
+	exitedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))  // Ghost row of a recently exited process
 
 	// Status and info styles.
 	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
@@ -24,6 +27,9 @@ var (
 	labelStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
 	dimStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 
+	// System header meters below 50%.
+	meterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+
 	// Group header in dev view.
 	groupStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
 )